@@ -0,0 +1,291 @@
+// cmd/add.go
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/NarmadaWeb/goback/pkg/config"
+	"github.com/NarmadaWeb/goback/pkg/project"
+	"github.com/NarmadaWeb/goback/pkg/scaffolding/generator"
+
+	"github.com/spf13/cobra"
+)
+
+// addCmd groups the commands that extend an existing project
+var addCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add code to an existing project",
+	Long:  `Adds new code to a project generated by GoBack. Run it from the project root.`,
+}
+
+// addResourceCmd generates a CRUD resource in the current project
+var addResourceCmd = &cobra.Command{
+	Use:   "resource [name]",
+	Short: "Add a CRUD resource to the current project",
+	Long: `Generates a CRUD resource in the project in the current directory.
+
+The framework, database, tool and architecture are detected from the project,
+and the entity, DTOs, repository, service, handlers, routes and migration are
+placed in the layers of its architecture.
+
+Field types: string, text, int, float, decimal, bool, time.`,
+	Example: `  goback add resource Product --fields name:string,price:decimal,stock:int`,
+	Args:    cobra.ExactArgs(1),
+	Run:     runAddResource,
+}
+
+func runAddResource(cmd *cobra.Command, args []string) {
+	fields, _ := cmd.Flags().GetString("fields")
+	force, _ := cmd.Flags().GetBool("force")
+
+	resource, err := generator.ParseResource(args[0], fields)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	manifest, err := project.LoadManifest(".")
+	if err != nil && !errors.Is(err, project.ErrNoManifest) {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	var cfg *config.ProjectConfig
+	if manifest != nil {
+		cfg = &manifest.Config
+	} else if cfg, err = project.Detect("."); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	} else {
+		manifest = project.NewManifest(cfg)
+	}
+
+	fmt.Printf("Adding resource '%s' to %s (%s / %s / %s / %s)...\n", resource.Name, cfg.ProjectName,
+		cfg.Framework, cfg.Database, cfg.Tool, cfg.Architecture)
+
+	gen := generator.NewResourceGenerator(cfg, resource)
+	gen.Force = force
+	gen.Manifest = manifest
+	gen.SetProgressCallback(func(step int, message string) {
+		fmt.Printf("  %s\n", message)
+	})
+
+	if err := gen.Generate(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := manifest.Save("."); err != nil {
+		fmt.Printf("Error: failed to update %s: %v\n", project.ManifestPath, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("\n✅ Resource '%s' added. Files created or updated:\n", resource.Name)
+	for _, file := range gen.Created() {
+		fmt.Printf("  %s\n", file)
+	}
+	if notes := gen.Notes(); len(notes) > 0 {
+		fmt.Printf("Next steps:\n")
+		for _, note := range notes {
+			fmt.Printf("  %s\n", note)
+		}
+	}
+	fmt.Printf("\nEndpoints: /api/v1/%s and /api/v1/%s/:id\n", resource.Path(), resource.Path())
+}
+
+// addDevOpsCmd generates DevOps tool files in the current project
+var addDevOpsCmd = &cobra.Command{
+	Use:   "devops [tool...]",
+	Short: "Add DevOps tool files to the current project",
+	Long: fmt.Sprintf(`Generates the files of one or more DevOps tools (%s) in the project in the
+current directory and records the tools in its configuration.
+
+Files that were changed since goback generated them are left untouched.`, strings.Join(config.GetValidDevOpsTools(), ", ")),
+	Example: `  goback add devops helm
+  goback add devops terraform ansible`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runAddGenerator(cmd, "DevOps tools "+strings.Join(args, ", "), func(cfg *config.ProjectConfig) error {
+			devops, err := cfg.DevOps.WithTools(args)
+			cfg.DevOps = devops
+			return err
+		}, func(gen *generator.TemplateGenerator) error {
+			return gen.GenerateDevOps(args)
+		})
+	},
+}
+
+// addFeatureCmd generates the files of a feature in the current project
+var addFeatureCmd = &cobra.Command{
+	Use:   "feature [name]",
+	Short: "Add a feature to the current project",
+	Long:  "Generates the files of a feature in the project in the current directory.\n\n" + featureList() + "\nFiles that were changed since goback generated them are left untouched.",
+	Example: `  goback add feature docker
+  goback add feature license --license mit --author "Acme Inc."`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runAddGenerator(cmd, "feature "+args[0], func(cfg *config.ProjectConfig) error {
+			flags := cmd.Flags()
+			if flags.Changed("license") {
+				license, _ := flags.GetString("license")
+				cfg.License = config.LicenseChoice(license)
+			}
+			if flags.Changed("author") {
+				cfg.Author, _ = flags.GetString("author")
+			}
+			return nil
+		}, func(gen *generator.TemplateGenerator) error {
+			return gen.GenerateFeature(args[0])
+		})
+	},
+}
+
+// featureList describes the features for the help text
+func featureList() string {
+	var b strings.Builder
+	b.WriteString("Features:\n")
+	for _, feature := range generator.GetFeatures() {
+		fmt.Fprintf(&b, "  %-10s %s\n", feature.Name, feature.Description)
+	}
+	return b.String()
+}
+
+// runAddGenerator applies change to the configuration of the project in the
+// current directory, runs generate against it and records the written files in
+// the project manifest. A change that breaks a rule of the organization policy
+// is refused before anything is written; rules the project already broke do
+// not block it.
+func runAddGenerator(cmd *cobra.Command, what string, change func(cfg *config.ProjectConfig) error,
+	generate func(gen *generator.TemplateGenerator) error) {
+	force, _ := cmd.Flags().GetBool("force")
+
+	manifest, err := project.LoadManifest(".")
+	if err != nil && !errors.Is(err, project.ErrNoManifest) {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	cfg, err := addProjectConfig(cmd, manifest)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	before := config.ActivePolicy().Check(cfg)
+	if err := change(cfg); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	var violations []config.PolicyViolation
+	for _, violation := range config.ActivePolicy().Check(cfg) {
+		if !slices.Contains(before, violation) {
+			violations = append(violations, violation)
+		}
+	}
+	if len(violations) > 0 {
+		fmt.Printf("❌ Adding %s breaks the organization policy:\n", what)
+		for _, violation := range violations {
+			fmt.Printf("  - %s\n", violation.Error())
+		}
+		os.Exit(1)
+	}
+
+	fmt.Printf("Adding %s to %s...\n", what, cfg.ProjectName)
+
+	gen := generator.NewTemplateGenerator(cfg)
+	gen.Protect = func(path string) bool {
+		if force {
+			return false
+		}
+		if _, err := os.Stat(filepath.FromSlash(path)); err != nil {
+			return false
+		}
+		// Without a manifest there is no way to tell generated files from the user's own
+		return manifest == nil || manifest.State(".", path) != project.FilePristine
+	}
+
+	if err := generate(gen); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if manifest == nil {
+		manifest = project.NewManifest(cfg)
+	}
+	manifest.Config = *cfg
+	for path, hash := range gen.Files() {
+		manifest.Files[path] = hash
+	}
+	if err := manifest.Save("."); err != nil {
+		fmt.Printf("Error: failed to update %s: %v\n", project.ManifestPath, err)
+		os.Exit(1)
+	}
+
+	written := make([]string, 0, len(gen.Files()))
+	for path := range gen.Files() {
+		written = append(written, path)
+	}
+	sort.Strings(written)
+
+	fmt.Printf("\n✅ Added %s.\n", what)
+	if len(written) > 0 {
+		fmt.Printf("Files written:\n")
+		for _, path := range written {
+			fmt.Printf("  %s\n", path)
+		}
+	}
+	if skipped := gen.Skipped(); len(skipped) > 0 {
+		fmt.Printf("Files modified or not generated by goback were left untouched (use --force to overwrite):\n")
+		for _, path := range skipped {
+			fmt.Printf("  %s\n", path)
+		}
+	}
+}
+
+// addProjectConfig returns the configuration of the project in the current directory,
+// taken from --from-file, the project manifest or the project files, in that order.
+func addProjectConfig(cmd *cobra.Command, manifest *project.Manifest) (*config.ProjectConfig, error) {
+	dir, err := filepath.Abs(".")
+	if err != nil {
+		return nil, err
+	}
+
+	fromFile, _ := cmd.Flags().GetString("from-file")
+	switch {
+	case fromFile != "":
+		cfg, err := config.LoadProjectConfig(fromFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load project definition %s: %w", fromFile, err)
+		}
+		cfg.OutputDir = dir
+		return cfg, nil
+	case manifest != nil:
+		cfg := manifest.Config
+		return &cfg, nil
+	default:
+		return project.Detect(dir)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(addCmd)
+	addCmd.AddCommand(addResourceCmd)
+	addCmd.AddCommand(addDevOpsCmd)
+	addCmd.AddCommand(addFeatureCmd)
+
+	for _, cmd := range []*cobra.Command{addDevOpsCmd, addFeatureCmd} {
+		cmd.Flags().String("from-file", "", "Project definition file to use instead of the recorded configuration")
+		cmd.Flags().Bool("force", false, "Overwrite files that were changed since generation")
+	}
+	addFeatureCmd.Flags().String("license", "", "License for the license feature")
+	addFeatureCmd.Flags().String("author", "", "Copyright holder for the license feature")
+
+	addResourceCmd.Flags().String("fields", "", "Comma separated name:type fields, e.g. name:string,price:decimal")
+	addResourceCmd.Flags().Bool("force", false, "Overwrite the files of an existing resource")
+	_ = addResourceCmd.MarkFlagRequired("fields")
+}
@@ -0,0 +1,93 @@
+// cmd/clean.go
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/NarmadaWeb/goback/pkg/project"
+	"github.com/NarmadaWeb/goback/pkg/scaffolding/generator"
+
+	"github.com/spf13/cobra"
+)
+
+// cleanCmd removes the unchanged generated files of the current project
+var cleanCmd = &cobra.Command{
+	Use:   "clean [path...]",
+	Short: "Remove the generated files that were not changed",
+	Long: `Removes the files goback generated in the project in the current directory,
+using the hashes recorded in .goback/project.json. Only files unchanged since
+generation are removed; modified files are listed and kept, and files goback did
+not generate are never touched. Directories left empty are removed too.
+
+Give paths to clean only part of the project, e.g. after trying a DevOps tool.
+DevOps tools whose files are all removed are dropped from the recorded
+configuration. When no generated file is left, .goback/project.json is removed
+as well.`,
+	Example: `  goback clean devops/terraform
+  goback clean --dry-run`,
+	Run: runClean,
+}
+
+func runClean(cmd *cobra.Command, args []string) {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	manifest, err := project.LoadManifest(".")
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	cleaner := generator.NewCleaner(".", manifest)
+	cleaner.DryRun = dryRun
+	cleaner.Match = func(path string) bool { return matchesPaths(path, args) }
+	if err := cleaner.Clean(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if !dryRun && !cleaner.Uninstalled() {
+		if err := manifest.Save("."); err != nil {
+			fmt.Printf("Error: failed to update %s: %v\n", project.ManifestPath, err)
+			os.Exit(1)
+		}
+	}
+
+	removed := cleaner.Removed()
+	switch {
+	case len(removed) == 0:
+		fmt.Printf("No unchanged generated files to remove.\n")
+	case dryRun:
+		fmt.Printf("Would remove %d unchanged generated files:\n", len(removed))
+	default:
+		fmt.Printf("✅ Removed %d unchanged generated files:\n", len(removed))
+	}
+	for _, path := range removed {
+		fmt.Printf("  %s\n", path)
+	}
+	if kept := cleaner.Kept(); len(kept) > 0 {
+		fmt.Printf("\nFiles modified since goback generated them were kept:\n")
+		for _, path := range kept {
+			fmt.Printf("  %s\n", path)
+		}
+	}
+	if missing := cleaner.Missing(); len(missing) > 0 {
+		fmt.Printf("\nGenerated files already deleted, no longer tracked:\n")
+		for _, path := range missing {
+			fmt.Printf("  %s\n", path)
+		}
+	}
+	if tools := cleaner.Tools(); len(tools) > 0 {
+		fmt.Printf("\nDevOps tools removed from the configuration: %s\n", strings.Join(tools, ", "))
+	}
+	if cleaner.Uninstalled() {
+		fmt.Printf("\nNo generated files are left, %s is removed too.\n", project.ManifestPath)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(cleanCmd)
+
+	cleanCmd.Flags().Bool("dry-run", false, "List the files that would be removed without removing them")
+}
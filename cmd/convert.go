@@ -0,0 +1,116 @@
+// cmd/convert.go
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/NarmadaWeb/goback/pkg/config"
+	"github.com/NarmadaWeb/goback/pkg/project"
+	"github.com/NarmadaWeb/goback/pkg/scaffolding/generator"
+
+	"github.com/spf13/cobra"
+)
+
+// convertCmd moves the current project to the layout of another architecture
+var convertCmd = &cobra.Command{
+	Use:   "convert",
+	Short: "Convert the current project to another architecture",
+	Long: `Moves the packages of the project in the current directory to the layout of
+another architecture, e.g. internal/handlers to interfaces/handlers and
+internal/repositories to infrastructure/repositories.
+
+Package clauses, imports and qualified identifiers are rewritten on the syntax
+tree, so the wiring in routes.Setup follows the moved packages. When the new
+architecture keeps the repository interfaces in the domain, they are split off
+from their implementations. Packages that are not a layer of the old
+architecture are left in place and reported. Nothing is written when files
+would conflict; use --dry-run to review the plan first.`,
+	Example: `  goback convert --to clean
+  goback convert --to hexagonal --dry-run`,
+	Args: cobra.NoArgs,
+	Run:  runConvert,
+}
+
+func runConvert(cmd *cobra.Command, args []string) {
+	to, _ := cmd.Flags().GetString("to")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if !config.IsValidArchitecture(config.ArchitectureChoice(to)) {
+		fmt.Println("Error: give the new architecture with --to (simple, ddd, clean, hexagonal)")
+		os.Exit(1)
+	}
+
+	manifest, err := project.LoadManifest(".")
+	if err != nil && !errors.Is(err, project.ErrNoManifest) {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	var cfg *config.ProjectConfig
+	if manifest != nil {
+		cfg = &manifest.Config
+	} else if cfg, err = project.Detect("."); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	converter := generator.NewConverter(".", cfg, config.ArchitectureChoice(to))
+	converter.Manifest = manifest
+	if err := converter.Plan(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	from, target := cfg.Architecture, config.ArchitectureChoice(to)
+	if dryRun {
+		fmt.Printf("Converting from %s to %s would:\n", from, target)
+	} else {
+		if err := converter.Convert(); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		if manifest != nil {
+			if err := manifest.Save("."); err != nil {
+				fmt.Printf("Error: failed to update %s: %v\n", project.ManifestPath, err)
+				os.Exit(1)
+			}
+		}
+		fmt.Printf("✅ Project converted from %s to %s.\n", from, target)
+	}
+
+	if moves := converter.Moves(); len(moves) > 0 {
+		fmt.Printf("\nFiles moved:\n")
+		for _, move := range moves {
+			fmt.Printf("  %s -> %s\n", move.From, move.To)
+		}
+	}
+	if rewritten := converter.Rewritten(); len(rewritten) > 0 {
+		fmt.Printf("\nFiles rewritten:\n")
+		for _, file := range rewritten {
+			fmt.Printf("  %s\n", file)
+		}
+	}
+	if extracted := converter.Extracted(); len(extracted) > 0 {
+		fmt.Printf("\nMoved from the repositories package to the domain:\n")
+		for _, name := range extracted {
+			fmt.Printf("  %s\n", name)
+		}
+	}
+	if notes := converter.Notes(); len(notes) > 0 {
+		fmt.Printf("\nLeft as it is, review by hand:\n")
+		for _, note := range notes {
+			fmt.Printf("  %s\n", note)
+		}
+	}
+	if !dryRun {
+		fmt.Printf("\nNext: go build ./... to check the result\n")
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(convertCmd)
+
+	convertCmd.Flags().String("to", "", "Architecture to convert to (simple, ddd, clean, hexagonal)")
+	convertCmd.Flags().Bool("dry-run", false, "Print the plan without changing any file")
+}
@@ -0,0 +1,107 @@
+// cmd/diff.go
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/NarmadaWeb/goback/pkg/project"
+	"github.com/NarmadaWeb/goback/pkg/scaffolding/generator"
+
+	"github.com/spf13/cobra"
+)
+
+// diffCmd shows how far the current project has drifted from its templates
+var diffCmd = &cobra.Command{
+	Use:   "diff [path...]",
+	Short: "Show drift between the current project and its templates",
+	Long: `Renders the templates in memory for the configuration recorded in
+.goback/project.json and compares them with the working tree.
+
+Each file is classified as:
+  pristine          unchanged since it was generated
+  modified          changed in the working tree
+  deleted           generated but removed from the working tree
+  changed upstream  the templates now render it differently
+  added upstream    the templates now render a file that was not generated
+  removed upstream  the templates no longer render it
+
+A unified diff from the rendered templates to the working tree is printed
+for every file that differs. Give paths to limit the comparison.`,
+	Example: `  goback diff
+  goback diff --summary
+  goback diff Makefile internal/`,
+	Run: runDiff,
+}
+
+func runDiff(cmd *cobra.Command, args []string) {
+	summary, _ := cmd.Flags().GetBool("summary")
+
+	manifest, err := project.LoadManifest(".")
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	cfg := manifest.Config
+	rendered, err := generator.NewTemplateGenerator(&cfg).Render()
+	if err != nil {
+		fmt.Printf("Error: failed to render templates: %v\n", err)
+		os.Exit(1)
+	}
+
+	var drifted []project.FileDrift
+	pristine := 0
+	for _, d := range manifest.Drift(".", rendered) {
+		if !matchesPaths(d.Path, args) {
+			continue
+		}
+		if d.State == project.FilePristine && !d.Upstream {
+			pristine++
+			continue
+		}
+		drifted = append(drifted, d)
+	}
+
+	fmt.Printf("%s: %d pristine, %d drifted\n", cfg.ProjectName, pristine, len(drifted))
+	for _, d := range drifted {
+		fmt.Printf("  %-28s %s\n", d.Status(), d.Path)
+	}
+	if summary {
+		return
+	}
+
+	for _, d := range drifted {
+		if !d.Changed() {
+			continue
+		}
+		diff, err := d.UnifiedDiff()
+		if err != nil {
+			fmt.Printf("Error: failed to diff %s: %v\n", d.Path, err)
+			os.Exit(1)
+		}
+		fmt.Printf("\n%s", diff)
+	}
+}
+
+// matchesPaths reports whether path is one of paths or inside one of them
+func matchesPaths(path string, paths []string) bool {
+	if len(paths) == 0 {
+		return true
+	}
+	for _, p := range paths {
+		p = strings.TrimSuffix(strings.TrimPrefix(p, "./"), "/")
+		if path == p || strings.HasPrefix(path, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().Bool("summary", false, "Only list the files and their status")
+}
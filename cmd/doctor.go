@@ -0,0 +1,82 @@
+// cmd/doctor.go
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/NarmadaWeb/goback/internal/doctor"
+	"github.com/NarmadaWeb/goback/pkg/config"
+	"github.com/NarmadaWeb/goback/pkg/project"
+
+	"github.com/spf13/cobra"
+)
+
+// doctorCmd checks the local toolchain needed by the current project
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the tools needed to work on the current project",
+	Long: `Checks for the binaries the project in the current directory depends on,
+such as go, make, sqlc, migrate, air, docker, helm, terraform and ansible,
+based on its framework, tool and DevOps configuration. Versions are reported
+and install commands are suggested for anything that is missing.
+
+Outside a project only the tools every project needs are checked. The command
+exits with status 1 when a required tool is missing.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := project.Load(".")
+		switch {
+		case errors.Is(err, project.ErrNotAProject):
+			cfg = &config.ProjectConfig{}
+		case err != nil:
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		default:
+			fmt.Printf("Checking tools for %s (%s / %s / %s / %s)\n\n", cfg.ProjectName,
+				cfg.Framework, cfg.Database, cfg.Tool, cfg.Architecture)
+		}
+
+		goVersion := ""
+		if mod, err := project.ReadGoMod("."); err == nil {
+			goVersion = mod.GoVersion
+		}
+
+		results := doctor.Run(cfg, goVersion)
+		printDoctorResults(results, true)
+
+		for _, result := range results {
+			if result.Required && !result.OK() {
+				os.Exit(1)
+			}
+		}
+	},
+}
+
+// printDoctorResults prints the outcome of the toolchain checks. Unless all is
+// set, only the tools that are missing or unusable are printed.
+func printDoctorResults(results []doctor.Result, all bool) {
+	for _, result := range results {
+		switch {
+		case result.OK():
+			if all {
+				fmt.Printf("  ✅ %-16s %-10s %s\n", result.Name, result.Version, result.Reason)
+			}
+		case result.Found:
+			fmt.Printf("  ⚠️  %-16s %-10s %s\n", result.Name, result.Version, result.Problem)
+			fmt.Printf("     install: %s\n", result.Install)
+		default:
+			marker := "❌"
+			if !result.Required {
+				marker = "➖"
+			}
+			fmt.Printf("  %s %-16s %-10s needed for %s\n", marker, result.Name, "missing", result.Reason)
+			fmt.Printf("     install: %s\n", result.Install)
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
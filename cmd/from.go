@@ -0,0 +1,116 @@
+// cmd/from.go
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/NarmadaWeb/goback/pkg/config"
+	"github.com/NarmadaWeb/goback/pkg/project"
+	"github.com/NarmadaWeb/goback/pkg/scaffolding/generator"
+)
+
+// createProjectFromModule creates a project by copying the reference module in src
+func createProjectFromModule(cfg *config.ProjectConfig, src, errorFormat string) {
+	srcDir := prepareCopiedProject(cfg, src, errorFormat)
+
+	fmt.Printf("Creating project '%s' from %s...\n", cfg.ProjectName, src)
+	gen := generator.NewTemplateGenerator(cfg)
+	if err := gen.CopyModule(srcDir); err != nil {
+		fmt.Printf("Error: failed to copy %s: %v\n", src, err)
+		os.Exit(1)
+	}
+	addCopiedToWorkspace(gen)
+	printCopiedProject(cfg, len(gen.Files()), "copied")
+}
+
+// createProjectFromPack creates a project from the template pack in dir
+func createProjectFromPack(cfg *config.ProjectConfig, dir, errorFormat string) {
+	packDir := prepareCopiedProject(cfg, dir, errorFormat)
+
+	fmt.Printf("Creating project '%s' from template %s...\n", cfg.ProjectName, dir)
+	gen := generator.NewTemplateGenerator(cfg)
+	if err := gen.GenerateFromPack(packDir); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	addCopiedToWorkspace(gen)
+	printCopiedProject(cfg, len(gen.Files()), "generated")
+}
+
+// copiedStackFields are the configuration fields a project created from a
+// module or template pack does not need
+var copiedStackFields = []string{"framework", "database", "tool", "architecture"}
+
+// prepareCopiedProject fills in the defaults for a project created from src,
+// validates them and returns the absolute path of src
+func prepareCopiedProject(cfg *config.ProjectConfig, src, errorFormat string) string {
+	if cfg.ModulePath == "" {
+		cfg.ModulePath = config.DefaultModulePath(cfg.ProjectName)
+	}
+	if cfg.OutputDir == "" {
+		cfg.OutputDir = "./" + cfg.ProjectName
+	}
+	if cfg.RepoMode == config.RepoPackage {
+		fmt.Println("Error: the package repository mode needs the built-in templates, use workspace or standalone with --from and --template")
+		os.Exit(1)
+	}
+	var errs config.ValidationErrors
+	for _, err := range config.ValidateNewProject(cfg) {
+		// The stack of a copied project is not generated, so it may be left out
+		if err.Code == config.CodeRequired && slices.Contains(copiedStackFields, err.Field) {
+			continue
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		printValidationErrors(errs, errorFormat)
+		os.Exit(1)
+	}
+
+	srcDir, err := filepath.Abs(src)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	outputDir, err := filepath.Abs(cfg.OutputDir)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if isWithin(srcDir, outputDir) {
+		fmt.Printf("Error: the output directory %s is inside %s\n", cfg.OutputDir, src)
+		os.Exit(1)
+	}
+	return srcDir
+}
+
+// addCopiedToWorkspace adds a copied project to the go.work of the enclosing repository in the workspace mode
+func addCopiedToWorkspace(gen *generator.TemplateGenerator) {
+	if gen.Config.RepoMode != config.RepoWorkspace {
+		return
+	}
+	if err := gen.AddToWorkspace(); err != nil {
+		fmt.Printf("Error: failed to update go.work: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// printCopiedProject prints the next steps for a project created from a module or pack
+func printCopiedProject(cfg *config.ProjectConfig, files int, verb string) {
+	fmt.Printf("\n✅ Project '%s' created successfully! (%d files %s)\n\n", cfg.ProjectName, files, verb)
+	fmt.Println("Next steps:")
+	fmt.Printf("  1. cd %s\n", cfg.OutputDir)
+	fmt.Println("  2. go mod tidy")
+	fmt.Printf("  3. goback inspect   # check the stack recorded in %s, which 'goback add' extends\n", project.ManifestPath)
+}
+
+// isWithin reports whether path is dir or inside it; both must be absolute
+func isWithin(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && (rel == "." || !strings.HasPrefix(rel, ".."))
+}
@@ -0,0 +1,149 @@
+// cmd/inspect.go
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/NarmadaWeb/goback/pkg/config"
+	"github.com/NarmadaWeb/goback/pkg/project"
+
+	"github.com/spf13/cobra"
+)
+
+// inspectCmd reports how a project was built
+var inspectCmd = &cobra.Command{
+	Use:   "inspect [dir]",
+	Short: "Show how a project was built",
+	Long: `Reports the kind, framework, database, tool, architecture and DevOps tools of the
+project in dir (default: the current directory).
+
+The configuration recorded in .goback/project.json is used when present.
+Otherwise it is detected from the go.mod requirements, the environment files
+and the directory layout, e.g. adapters/primary/http for hexagonal or
+domain/usecases for clean architecture.
+
+Use --write to record the detected configuration for a project that was not
+generated by goback, so that 'goback add' can work on it. Settings that cannot
+be detected can be given with flags; for a recorded project they update the
+recorded configuration.`,
+	Example: `  goback inspect ../billing-service
+  goback inspect --write --architecture clean`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runInspect,
+}
+
+func runInspect(cmd *cobra.Command, args []string) {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	write, _ := cmd.Flags().GetBool("write")
+
+	inspection, err := project.Inspect(dir)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	cfg := inspection.Config
+
+	flags := cmd.Flags()
+	overridden := map[string]bool{}
+	for _, name := range []string{"kind", "framework", "database", "tool", "architecture"} {
+		if !flags.Changed(name) {
+			continue
+		}
+		value, _ := flags.GetString(name)
+		switch name {
+		case "kind":
+			cfg.Kind = config.ProjectKind(value)
+		case "framework":
+			cfg.Framework = config.FrameworkChoice(value)
+		case "database":
+			cfg.Database = config.DatabaseChoice(value)
+		case "tool":
+			cfg.Tool = config.ToolChoice(value)
+		case "architecture":
+			cfg.Architecture = config.ArchitectureChoice(value)
+		}
+		overridden[name] = true
+	}
+
+	source := "detected from the project files"
+	if inspection.Recorded {
+		source = "recorded in " + project.ManifestPath
+	}
+	fmt.Printf("Project:      %s (%s)\n", cfg.ProjectName, cfg.ModulePath)
+	fmt.Printf("Source:       %s\n\n", source)
+
+	printSetting := func(label, key, value string) {
+		switch {
+		case value == "" && key == "kind":
+			value = string(config.KindAPI)
+		case value == "" && (key == "devops" || key == "framework" && !cfg.Kind.HasAPI()):
+			value = "none"
+		case value == "":
+			value = "unknown"
+		case overridden[key]:
+			value += "  (from --" + key + ")"
+		case inspection.Evidence[key] != "":
+			value += "  (" + inspection.Evidence[key] + ")"
+		}
+		fmt.Printf("%-13s %s\n", label+":", value)
+	}
+	printSetting("Kind", "kind", string(cfg.Kind))
+	printSetting("Framework", "framework", string(cfg.Framework))
+	printSetting("Database", "database", string(cfg.Database))
+	printSetting("Tool", "tool", string(cfg.Tool))
+	printSetting("Architecture", "architecture", string(cfg.Architecture))
+	printSetting("Go", "go", cfg.GoVersion)
+	printSetting("DevOps", "devops", strings.Join(cfg.DevOps.Tools, ", "))
+
+	if !write {
+		if !inspection.Recorded {
+			fmt.Printf("\nRun 'goback inspect --write' to record this configuration in %s.\n", project.ManifestPath)
+		}
+		return
+	}
+
+	if missing := inspection.Missing(); len(missing) > 0 {
+		fmt.Printf("\nError: could not detect the project's %s, set it with --%s\n",
+			strings.Join(missing, ", "), missing[0])
+		os.Exit(1)
+	}
+	if validationErrors := config.ValidateProjectConfig(cfg); len(validationErrors) > 0 {
+		fmt.Println()
+		printValidationErrors(validationErrors, "text")
+		os.Exit(1)
+	}
+
+	// A new manifest records no files, so goback treats all existing files as the user's own
+	manifest, err := project.LoadManifest(dir)
+	if errors.Is(err, project.ErrNoManifest) {
+		manifest, err = project.NewManifest(cfg), nil
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	manifest.Config = *cfg
+	if err := manifest.Save(dir); err != nil {
+		fmt.Printf("Error: failed to write %s: %v\n", project.ManifestPath, err)
+		os.Exit(1)
+	}
+	fmt.Printf("\n✅ Configuration recorded in %s\n", project.ManifestPath)
+}
+
+func init() {
+	rootCmd.AddCommand(inspectCmd)
+
+	inspectCmd.Flags().Bool("write", false, "Record the configuration in "+project.ManifestPath)
+	inspectCmd.Flags().String("kind", "", "Project kind to record when it cannot be detected")
+	inspectCmd.Flags().String("framework", "", "Framework to record when it cannot be detected")
+	inspectCmd.Flags().String("database", "", "Database to record when it cannot be detected")
+	inspectCmd.Flags().String("tool", "", "Tool to record when it cannot be detected")
+	inspectCmd.Flags().String("architecture", "", "Architecture to record when it cannot be detected")
+}
@@ -0,0 +1,96 @@
+// cmd/pack.go
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/NarmadaWeb/goback/pkg/scaffolding/generator"
+
+	"github.com/spf13/cobra"
+)
+
+// packCmd groups the template pack commands
+var packCmd = &cobra.Command{
+	Use:   "pack",
+	Short: "Work with template packs",
+	Long: `Template packs are project templates made from an existing project. Export
+a golden service with 'goback pack export' and generate new projects from it
+with 'goback new --template <dir>'.`,
+}
+
+// packExportCmd turns a project into a template pack
+var packExportCmd = &cobra.Command{
+	Use:   "export [dir]",
+	Short: "Export a project as a template pack",
+	Long: `Walks the project in dir (default: the current directory) and writes a
+template pack: a ` + generator.PackDescriptor + ` descriptor and the project files as
+templates. The module path and the project name, in kebab, snake and title
+case (billing-service, billing_service, Billing Service), are replaced with
+template expressions in file contents and paths. Files matched by
+` + generator.IgnoreFile + ` are left out.`,
+	Example: `  goback pack export ./billing-service --name acme-api
+  goback new orders --template ./acme-api --module github.com/acme/orders`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runPackExport,
+}
+
+func runPackExport(cmd *cobra.Command, args []string) {
+	src := "."
+	if len(args) > 0 {
+		src = args[0]
+	}
+	name, _ := cmd.Flags().GetString("name")
+	description, _ := cmd.Flags().GetString("description")
+	dest, _ := cmd.Flags().GetString("output")
+	if dest == "" {
+		dest = "./" + name
+	}
+
+	srcDir, err := filepath.Abs(src)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	destDir, err := filepath.Abs(dest)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if isWithin(srcDir, destDir) {
+		fmt.Printf("Error: the output directory %s is inside the project %s\n", dest, src)
+		os.Exit(1)
+	}
+	if entries, err := os.ReadDir(destDir); err == nil && len(entries) > 0 {
+		fmt.Printf("Error: the output directory %s is not empty\n", dest)
+		os.Exit(1)
+	}
+
+	pack := &generator.Pack{Name: name, Description: description}
+	files, err := generator.ExportPack(srcDir, destDir, pack)
+	if err != nil {
+		fmt.Printf("Error: failed to export %s: %v\n", src, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✅ Template pack '%s' written to %s (%d files)\n", name, dest, files)
+	if generator.IsLayoutName(pack.ProjectName) {
+		fmt.Printf("   Templated module path %s\n", pack.ModulePath)
+		fmt.Printf("   ⚠️  The project name %s is also a name of the generated layout, so it was kept as it is\n\n", pack.ProjectName)
+	} else {
+		fmt.Printf("   Templated module path %s and project name %s\n\n", pack.ModulePath, pack.ProjectName)
+	}
+	fmt.Printf("Generate a project from it with:\n  goback new <project-name> --template %s\n", dest)
+}
+
+func init() {
+	rootCmd.AddCommand(packCmd)
+	packCmd.AddCommand(packExportCmd)
+
+	packExportCmd.Flags().String("name", "", "Name of the template pack")
+	packExportCmd.Flags().String("description", "", "Description of the template pack")
+	packExportCmd.Flags().StringP("output", "o", "", "Directory to write the pack to (default: ./<name>)")
+	_ = packExportCmd.MarkFlagRequired("name")
+}
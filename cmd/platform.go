@@ -0,0 +1,167 @@
+// cmd/platform.go
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/NarmadaWeb/goback/internal/utils"
+	"github.com/NarmadaWeb/goback/pkg/config"
+	"github.com/NarmadaWeb/goback/pkg/project"
+	"github.com/NarmadaWeb/goback/pkg/scaffolding/generator"
+
+	"github.com/spf13/cobra"
+)
+
+// addServiceCmd generates a service in the current monorepo platform
+var addServiceCmd = &cobra.Command{
+	Use:   "service [name]",
+	Short: "Add a service to the current monorepo platform",
+	Long: `Generates a service in services/<name> of the platform in the current directory,
+created with 'goback new <name> --monorepo'.
+
+Every service has its own kind, framework, database, tool and architecture. It is
+added to the root go.work, gets the next free port, and the platform's
+docker-compose.yml and Helm values are rewritten to include it, unless they were
+changed since goback generated them.`,
+	Example: `  goback add service users -f chi -d postgresql -t gorm -a ddd
+  goback add service mailer --kind worker -d postgresql -t sqlx -a simple`,
+	Args: cobra.ExactArgs(1),
+	Run:  runAddService,
+}
+
+// createPlatform creates the shared base of a monorepo platform
+func createPlatform(cmd *cobra.Command, name string) {
+	flags := cmd.Flags()
+
+	dir, _ := flags.GetString("output")
+	if dir == "" {
+		dir = "./" + name
+	}
+	modulePath, _ := flags.GetString("module")
+	if modulePath == "" {
+		modulePath = config.DefaultModulePath(name)
+	}
+	goVersion, _ := flags.GetString("go-version")
+	if goVersion == "" {
+		goVersion = config.DetectGoVersion()
+	}
+
+	if err := utils.ValidateProjectName(name); err != nil {
+		fmt.Printf("Error: invalid platform name: %v\n", err)
+		os.Exit(1)
+	}
+	if err := utils.ValidateModulePath(modulePath); err != nil {
+		fmt.Printf("Error: invalid module path: %v\n", err)
+		os.Exit(1)
+	}
+	goVersion, err := config.NormalizeGoVersion(goVersion)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if _, err := os.Stat(filepath.Join(dir, project.PlatformPath)); err == nil {
+		fmt.Printf("Error: %s already is a platform, add services with 'goback add service'\n", dir)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Creating platform '%s'...\n", name)
+
+	gen := generator.NewPlatformGenerator(project.NewPlatform(name, modulePath, goVersion), dir)
+	if err := gen.Generate(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("\n✅ Platform '%s' created successfully!\n\n", name)
+	fmt.Printf("Add services from the platform root:\n")
+	fmt.Printf("  cd %s\n", dir)
+	fmt.Printf("  goback add service users -f chi -d postgresql -t gorm -a ddd\n")
+}
+
+func runAddService(cmd *cobra.Command, args []string) {
+	force, _ := cmd.Flags().GetBool("force")
+	errorFormat := errorFormatFlag(cmd)
+
+	platform, err := project.LoadPlatform(".")
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if platform.Service(args[0]) != nil {
+		fmt.Printf("Error: the platform already has a service named %s\n", args[0])
+		os.Exit(1)
+	}
+
+	pg := generator.NewPlatformGenerator(platform, ".")
+	pg.Force = force
+
+	cfg := pg.ServiceConfig(args[0])
+	if err := applyProjectFlags(cmd, cfg); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if cfg.Kind == "" {
+		cfg.Kind = config.KindAPI
+	}
+	if isInteractive() {
+		promptMissingChoices(cfg)
+	}
+	if cfg.License != "" && cfg.Author == "" {
+		cfg.Author = config.GetConfig().DefaultAuthor
+	}
+
+	if validationErrors := config.ValidateNewProject(cfg); len(validationErrors) > 0 {
+		printValidationErrors(validationErrors, errorFormat)
+		os.Exit(1)
+	}
+	if entity := cfg.SampleEntity(); entity != "" {
+		if _, err := generator.ParseResource(entity, cfg.SampleFieldSpec()); err != nil {
+			fmt.Printf("Error: invalid sample: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	fmt.Printf("Adding service '%s' to %s (%s)...\n", cfg.ProjectName, platform.Name, cfg.OutputDir)
+
+	gen := generator.NewTemplateGenerator(cfg)
+	gen.SetProgressCallback(func(step int, message string) {
+		fmt.Printf("  %s\n", message)
+	})
+	if err := pg.AddService(gen); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	service := platform.Service(cfg.ProjectName)
+	fmt.Printf("\n✅ Service '%s' added on port %d.\n\n", service.Name, service.Port)
+	fmt.Printf("  cd %s\n\n", filepath.ToSlash(service.Path))
+	fmt.Print(gen.Report().Text())
+	if skipped := pg.Skipped(); len(skipped) > 0 {
+		fmt.Printf("\nPlatform files changed since goback generated them were left untouched (use --force to overwrite):\n")
+		for _, path := range skipped {
+			fmt.Printf("  %s\n", path)
+		}
+	}
+}
+
+func init() {
+	addCmd.AddCommand(addServiceCmd)
+
+	flags := addServiceCmd.Flags()
+	flags.String("kind", "", "Kind of service (api, worker, cli, api+worker; default: api)")
+	flags.StringP("framework", "f", "", "Framework to use (fiber, gin, chi, echo), not used by worker and cli services")
+	flags.StringP("database", "d", "", "Database to use (postgresql, mysql, sqlite)")
+	flags.StringP("tool", "t", "", "Tool to use (sqlx, sqlc)")
+	flags.StringP("architecture", "a", "", "Architecture pattern (simple, ddd, clean, hexagonal)")
+	flags.String("license", "", "License to generate (mit, apache-2.0, bsd-3, mpl-2.0, proprietary)")
+	flags.String("author", "", "Copyright holder for the license (defaults to default_author)")
+	flags.String("sample", "", "Sample domain: none, user or a custom entity name like Product (default: user)")
+	flags.String("sample-fields", "", "Fields of a custom sample entity (e.g. name:string,price:decimal)")
+	flags.StringArray("set", []string{}, "Set a template value (key=value, may be repeated), e.g. port=9000")
+	flags.StringArray("values", []string{}, "Template values file (YAML or JSON, may be repeated)")
+	flags.String("error-format", "text", "Format of validation errors (text, json)")
+	flags.Bool("force", false, "Overwrite platform files that were changed since generation")
+}
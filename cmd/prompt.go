@@ -0,0 +1,112 @@
+// cmd/prompt.go
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/NarmadaWeb/goback/pkg/config"
+	"github.com/NarmadaWeb/goback/pkg/project"
+
+	"golang.org/x/term"
+)
+
+// promptChoice is implemented by the project choice types in config
+type promptChoice interface {
+	~string
+	String() string
+	Description() string
+}
+
+// isInteractive reports whether the user can be prompted on stdin
+func isInteractive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// promptMissingChoices asks line by line for the stack choices that were not given as flags,
+// and for the repository mode when the output directory is inside a module or git repository.
+// Choices the organization policy forbids are not offered.
+func promptMissingChoices(cfg *config.ProjectConfig) {
+	reader := bufio.NewReader(os.Stdin)
+	policy := config.ActivePolicy()
+
+	if cfg.Kind.HasAPI() && cfg.Framework == "" {
+		cfg.Framework = promptFor(reader, "Framework",
+			config.AllowedChoices(policy, config.PolicyFrameworks, config.GetValidFrameworks()), config.FrameworkFiber)
+	}
+	if cfg.Database == "" {
+		cfg.Database = promptFor(reader, "Database",
+			config.AllowedChoices(policy, config.PolicyDatabases, config.GetValidDatabases()), config.DatabasepostgresQL)
+	}
+	if cfg.Tool == "" {
+		cfg.Tool = promptFor(reader, "Database tool",
+			config.AllowedChoices(policy, config.PolicyTools, config.GetValidTools()), config.GetRecommendedTool(cfg.Database))
+	}
+	if cfg.Architecture == "" {
+		cfg.Architecture = promptFor(reader, "Architecture",
+			config.AllowedChoices(policy, config.PolicyArchitectures, config.GetValidArchitectures()), config.ArchitectureSimple)
+	}
+	if cfg.RepoMode == "" && cfg.OutputDir != "" {
+		if repo, err := project.DetectRepo(cfg.OutputDir); err == nil && repo.Enclosed() {
+			fmt.Printf("\n%s is inside %s.\n", cfg.OutputDir, describeRepo(repo))
+			cfg.RepoMode = promptFor(reader, "Repository mode", repo.Modes(), config.RepoWorkspace)
+		}
+	}
+}
+
+// describeRepo names what encloses an output directory, for the repository mode prompt
+func describeRepo(repo *project.Repo) string {
+	switch {
+	case repo.ModuleRoot != "":
+		return fmt.Sprintf("the Go module %s in %s", repo.Module, repo.ModuleRoot)
+	case repo.WorkFile != "":
+		return "the Go workspace " + repo.WorkFile
+	default:
+		return "the git repository in " + repo.GitRoot
+	}
+}
+
+// promptFor prints the choices with their descriptions and reads the user's pick.
+// The answer may be the list number or the choice value; an empty answer selects def,
+// or the first choice when def is not among the choices.
+func promptFor[T promptChoice](reader *bufio.Reader, label string, choices []T, def T) T {
+	if !slices.Contains(choices, def) {
+		def = choices[0]
+	}
+	fmt.Printf("\n%s:\n", label)
+	for i, choice := range choices {
+		marker := " "
+		if choice == def {
+			marker = "*"
+		}
+		fmt.Printf(" %s %d. %-10s %-28s %s\n", marker, i+1, string(choice), choice.String(), choice.Description())
+	}
+
+	for {
+		fmt.Printf("Select %s [%s]: ", strings.ToLower(label), string(def))
+		line, err := reader.ReadString('\n')
+		answer := strings.ToLower(strings.TrimSpace(line))
+		if err != nil && answer == "" {
+			fmt.Println("\nError: no selection made")
+			os.Exit(1)
+		}
+
+		if answer == "" {
+			return def
+		}
+		if index, convErr := strconv.Atoi(answer); convErr == nil && index >= 1 && index <= len(choices) {
+			return choices[index-1]
+		}
+		for _, choice := range choices {
+			if answer == string(choice) {
+				return choice
+			}
+		}
+		fmt.Printf("Invalid choice %q, enter a number between 1 and %d or one of the values above.\n", answer, len(choices))
+	}
+}
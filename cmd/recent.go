@@ -0,0 +1,134 @@
+// cmd/recent.go
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/NarmadaWeb/goback/internal/utils"
+	"github.com/NarmadaWeb/goback/pkg/config"
+
+	"github.com/spf13/cobra"
+)
+
+// recentCmd lists recently generated projects
+var recentCmd = &cobra.Command{
+	Use:   "recent",
+	Short: "List recently generated projects",
+	Long: `Lists the projects recently generated by GoBack, newest first.
+
+Use the open and regenerate subcommands with the number shown in the list
+to re-open a project or generate it again with the same configuration.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		projects := config.GetRecentProjects()
+		if len(projects) == 0 {
+			fmt.Println("No recent projects yet. Create one with 'goback new' or 'goback tui'.")
+			return
+		}
+
+		fmt.Println("Recent projects:")
+		for i, project := range projects {
+			name := project.Config.ProjectName
+			if name == "" {
+				name = utils.GetFileName(project.Path)
+			}
+			fmt.Printf("  %2d. %-25s %s\n", i+1, name, project.Path)
+			if project.Config.Framework != "" {
+				fmt.Printf("      %s", project.Summary())
+				if !project.GeneratedAt.IsZero() {
+					fmt.Printf(" (%s)", project.GeneratedAt.Format("2006-01-02 15:04"))
+				}
+				fmt.Println()
+			}
+		}
+	},
+}
+
+// recentOpenCmd re-opens a recent project
+var recentOpenCmd = &cobra.Command{
+	Use:   "open [number]",
+	Short: "Re-open a recent project",
+	Long: `Opens a recent project in $EDITOR. When no editor is configured, the
+project path is printed so it can be used with 'cd $(goback recent open 1)'.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		project := getRecentProject(args[0])
+
+		editor := strings.Fields(os.Getenv("EDITOR"))
+		if len(editor) == 0 {
+			fmt.Println(project.Path)
+			return
+		}
+
+		editorArgs := append(editor[1:], project.Path)
+		if err := utils.RunCommand(editor[0], editorArgs...); err != nil {
+			fmt.Printf("Error: failed to open %s: %v\n", project.Path, err)
+			os.Exit(1)
+		}
+	},
+}
+
+// recentRegenerateCmd regenerates a recent project with the same configuration
+var recentRegenerateCmd = &cobra.Command{
+	Use:   "regenerate [number]",
+	Short: "Regenerate a recent project with the same configuration",
+	Long: `Generates a recent project again using the configuration it was created with.
+
+Use --name to clone the configuration into a new project instead of
+regenerating the original one in place.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		project := getRecentProject(args[0])
+		if project.Config.Framework == "" {
+			fmt.Println("Error: this entry was recorded without its configuration and cannot be regenerated")
+			os.Exit(1)
+		}
+
+		cfg := project.Config
+		name, _ := cmd.Flags().GetString("name")
+		if name != "" {
+			cfg = *config.CloneProjectConfig(&project.Config, name)
+		}
+		if output, _ := cmd.Flags().GetString("output"); output != "" {
+			cfg.OutputDir = output
+		}
+		if module, _ := cmd.Flags().GetString("module"); module != "" {
+			cfg.ModulePath = module
+		}
+
+		if name == "" {
+			fmt.Printf("Regenerating '%s' in %s...\n", cfg.ProjectName, cfg.OutputDir)
+		} else {
+			fmt.Printf("Cloning '%s' as '%s'...\n", project.Config.ProjectName, cfg.ProjectName)
+		}
+		if validationErrors := config.ValidateNewProject(&cfg); len(validationErrors) > 0 {
+			printValidationErrors(validationErrors, "text")
+			os.Exit(1)
+		}
+		generateProject(&cfg)
+	},
+}
+
+// getRecentProject resolves a 1-based list number to a recent project
+func getRecentProject(arg string) config.RecentProject {
+	projects := config.GetRecentProjects()
+	index, err := strconv.Atoi(arg)
+	if err != nil || index < 1 || index > len(projects) {
+		fmt.Printf("Error: no recent project with number %s (see 'goback recent')\n", arg)
+		os.Exit(1)
+	}
+	return projects[index-1]
+}
+
+func init() {
+	rootCmd.AddCommand(recentCmd)
+	recentCmd.AddCommand(recentOpenCmd)
+	recentCmd.AddCommand(recentRegenerateCmd)
+
+	recentRegenerateCmd.Flags().String("name", "", "Clone the configuration under a new project name")
+	recentRegenerateCmd.Flags().StringP("output", "O", "", "Output directory")
+	recentRegenerateCmd.Flags().StringP("module", "m", "", "Go module path")
+}
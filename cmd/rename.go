@@ -0,0 +1,109 @@
+// cmd/rename.go
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/NarmadaWeb/goback/internal/utils"
+	"github.com/NarmadaWeb/goback/pkg/project"
+	"github.com/NarmadaWeb/goback/pkg/scaffolding/generator"
+
+	"github.com/spf13/cobra"
+)
+
+// renameCmd changes the module path and the name of the current project
+var renameCmd = &cobra.Command{
+	Use:   "rename",
+	Short: "Rename the current project and its module",
+	Long: `Renames the project in the current directory.
+
+--module rewrites the module path in go.mod and every import of the module's
+packages, parsing the Go files rather than replacing text. --name replaces the
+project name, as a whole word in its kebab, snake, title and upper case forms,
+in the Dockerfile, docker-compose.yml, the Helm chart, the Terraform variables
+and the Ansible inventory. Other files that still mention the old name are
+listed for review. The project directory keeps its name.`,
+	Example: `  goback rename --module github.com/acme/billing --name billing`,
+	Args:    cobra.NoArgs,
+	Run:     runRename,
+}
+
+func runRename(cmd *cobra.Command, args []string) {
+	newModule, _ := cmd.Flags().GetString("module")
+	newName, _ := cmd.Flags().GetString("name")
+	if newModule == "" && newName == "" {
+		fmt.Println("Error: give the new module path with --module, the new name with --name, or both")
+		os.Exit(1)
+	}
+	if newModule != "" {
+		if err := utils.ValidateModulePath(newModule); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if newName != "" {
+		if err := utils.ValidateProjectName(newName); err != nil {
+			fmt.Printf("Error: invalid project name: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	mod, err := project.ReadGoMod(".")
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	manifest, err := project.LoadManifest(".")
+	if err != nil && !errors.Is(err, project.ErrNoManifest) {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	name := project.ModuleName(mod.Module)
+	if manifest != nil {
+		name = manifest.Config.ProjectName
+	}
+
+	renamer := generator.NewRenamer(".", mod.Module, newModule, name, newName)
+	renamer.Manifest = manifest
+	if err := renamer.Rename(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if manifest != nil {
+		if err := manifest.Save("."); err != nil {
+			fmt.Printf("Error: failed to update %s: %v\n", project.ManifestPath, err)
+			os.Exit(1)
+		}
+	}
+
+	if newModule != "" && newModule != mod.Module {
+		fmt.Printf("Module: %s -> %s\n", mod.Module, newModule)
+	}
+	if newName != "" && newName != name {
+		fmt.Printf("Name:   %s -> %s\n", name, newName)
+	}
+	if changed := renamer.Changed(); len(changed) > 0 {
+		fmt.Printf("\n✅ Project renamed. Files rewritten:\n")
+		for _, file := range changed {
+			fmt.Printf("  %s\n", file)
+		}
+	} else {
+		fmt.Printf("\n✅ No files needed rewriting.\n")
+	}
+	if mentions := renamer.Mentions(); len(mentions) > 0 {
+		fmt.Printf("\nThese files still mention '%s', review them:\n", name)
+		for _, file := range mentions {
+			fmt.Printf("  %s\n", file)
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(renameCmd)
+
+	renameCmd.Flags().StringP("module", "m", "", "New Go module path")
+	renameCmd.Flags().String("name", "", "New project name")
+}
@@ -3,16 +3,21 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 
+	"github.com/NarmadaWeb/goback/internal/doctor"
 	"github.com/NarmadaWeb/goback/internal/tui"
 	"github.com/NarmadaWeb/goback/pkg/config"
+	"github.com/NarmadaWeb/goback/pkg/project"
 	"github.com/NarmadaWeb/goback/pkg/scaffolding/generator"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 )
 
 var cfgFile string
@@ -58,10 +63,23 @@ var newCmd = &cobra.Command{
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List available frameworks, databases, and architectures",
-	Long:  `Lists the available frameworks, databases, tools, and architectures.`,
+	Long: `Lists the available project kinds, frameworks, databases, tools, and architectures.
+
+With --output json or yaml every choice is printed with its ID, display name,
+description and capabilities, together with the supported combinations.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		if output != "text" {
+			printCatalog(output)
+			return
+		}
+
 		fmt.Println("GoBack offers the following options for your project:")
 
+		// Project kinds
+		fmt.Println("\n📦 Project Kinds:")
+		printChoiceList(config.GetValidProjectKinds())
+
 		// Frameworks
 		fmt.Println("\n🏗️ Frameworks:")
 		printChoiceList(config.GetValidFrameworks())
@@ -104,6 +122,8 @@ func printChoiceList[T any](items []T) {
 	for _, item := range items {
 		var choiceItem choice
 		switch v := any(item).(type) {
+		case config.ProjectKind:
+			choiceItem = v
 		case config.FrameworkChoice:
 			choiceItem = v
 		case config.DatabaseChoice:
@@ -122,6 +142,29 @@ func printChoiceList[T any](items []T) {
 	}
 }
 
+// printCatalog prints the catalog of all choices as json or yaml
+func printCatalog(format string) {
+	var (
+		data []byte
+		err  error
+	)
+	switch format {
+	case "json":
+		data, err = json.MarshalIndent(config.GetCatalog(), "", "  ")
+		data = append(data, '\n')
+	case "yaml":
+		data, err = yaml.Marshal(config.GetCatalog())
+	default:
+		fmt.Printf("Error: unknown output format '%s' (choose from: text, json, yaml)\n", format)
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Print(string(data))
+}
+
 // configCmd manages configuration
 var configCmd = &cobra.Command{
 	Use:   "config",
@@ -137,6 +180,9 @@ var configShowCmd = &cobra.Command{
 		fmt.Printf("Configuration file: %s\n", viper.ConfigFileUsed())
 		fmt.Printf("Default output directory: %s\n", cfg.DefaultOutputDir)
 		fmt.Printf("Default module prefix: %s\n", cfg.DefaultModulePrefix)
+		if policy := config.ActivePolicy(); policy != nil {
+			fmt.Printf("Policy: %s\n", policy.Path)
+		}
 	},
 }
 
@@ -179,6 +225,7 @@ func init() {
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.goback.yaml)")
 	rootCmd.PersistentFlags().Bool("verbose", false, "verbose output")
+	rootCmd.PersistentFlags().String("policy", "", "organization policy file (default is policy in the config file)")
 
 	// Add subcommands
 	rootCmd.AddCommand(tuiCmd)
@@ -191,16 +238,35 @@ func init() {
 	configCmd.AddCommand(configShowCmd)
 	configCmd.AddCommand(configSetCmd)
 
+	// List command flags
+	listCmd.Flags().StringP("output", "o", "text", "Output format (text, json, yaml)")
+
 	// New command flags
-	newCmd.Flags().StringP("framework", "f", "", "Framework to use (fiber, gin, chi, echo)")
+	newCmd.Flags().String("kind", "", "Kind of project (api, worker, cli, api+worker; default: api)")
+	newCmd.Flags().StringP("framework", "f", "", "Framework to use (fiber, gin, chi, echo), ignored for worker and cli projects")
 	newCmd.Flags().StringP("database", "d", "", "Database to use (postgresql, mysql, sqlite)")
 	newCmd.Flags().StringP("tool", "t", "", "Tool to use (sqlx, sqlc)")
 	newCmd.Flags().StringP("architecture", "a", "", "Architecture pattern (simple, ddd, clean, hexagonal)")
 	newCmd.Flags().StringP("output", "O", "", "Output directory")
 	newCmd.Flags().StringP("module", "m", "", "Go module path")
+	newCmd.Flags().String("repo-mode", "", "Inside an existing Go module or git repository: workspace (nested module in go.work), package (no go.mod) or standalone")
 	newCmd.Flags().Bool("devops", false, "Include DevOps configurations")
 	newCmd.Flags().StringSlice("devops-tools", []string{},
 		"DevOps tools to include (helm, terraform, ansible)")
+	newCmd.Flags().String("license", "", "License to generate (mit, apache-2.0, bsd-3, mpl-2.0, proprietary)")
+	newCmd.Flags().String("author", "", "Copyright holder for the license (defaults to default_author)")
+	newCmd.Flags().Bool("spdx-header", false, "Add an SPDX license header to generated Go files")
+	newCmd.Flags().String("go-version", "", "Go version for go.mod, the toolchain and the builder images (default: the installed Go)")
+	newCmd.Flags().String("sample", "", "Sample domain: none, user or a custom entity name like Product (default: user)")
+	newCmd.Flags().String("sample-fields", "", "Fields of a custom sample entity (e.g. name:string,price:decimal, default: "+config.DefaultSampleFields+")")
+	newCmd.Flags().String("from", "", "Copy an existing Go module instead of using the templates (honors "+generator.IgnoreFile+")")
+	newCmd.Flags().String("template", "", "Template pack directory to generate from (see 'goback pack export')")
+	newCmd.Flags().String("from-file", "", "Project definition file (YAML or JSON, see 'goback schema project')")
+	newCmd.Flags().StringArray("set", []string{}, "Set a template value (key=value, may be repeated)")
+	newCmd.Flags().StringArray("values", []string{}, "Template values file (YAML or JSON, may be repeated)")
+	newCmd.Flags().Bool("monorepo", false, "Create a monorepo platform with a go.work, shared packages, Docker Compose and a Helm chart (add services with 'goback add service')")
+	newCmd.Flags().String("error-format", "text", "Format of validation errors (text, json)")
+	newCmd.MarkFlagsMutuallyExclusive("from", "template")
 
 	// Bind flags to viper
 	_ = viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
@@ -242,6 +308,59 @@ func initConfig() {
 
 	// Initialize default configuration
 	config.InitDefaults()
+
+	loadPolicy()
+}
+
+// loadPolicy enforces the policy file given with --policy or named in the config file
+func loadPolicy() {
+	path, _ := rootCmd.PersistentFlags().GetString("policy")
+	if path == "" {
+		path = config.GetConfig().Policy
+		// A policy named in the config file is relative to that file
+		if path != "" && !filepath.IsAbs(path) && viper.ConfigFileUsed() != "" {
+			path = filepath.Join(filepath.Dir(viper.ConfigFileUsed()), path)
+		}
+	}
+	if path == "" {
+		return
+	}
+
+	policy, err := config.LoadPolicy(path)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	config.SetPolicy(policy)
+}
+
+// errorFormatFlag returns the format of validation errors given with --error-format,
+// failing on formats other than text and json before anything is generated
+func errorFormatFlag(cmd *cobra.Command) string {
+	format, _ := cmd.Flags().GetString("error-format")
+	if format != "text" && format != "json" {
+		fmt.Printf("Error: unknown error format '%s' (choose from: text, json)\n", format)
+		os.Exit(1)
+	}
+	return format
+}
+
+// printValidationErrors reports validation errors as text, or as JSON with their codes for scripts
+func printValidationErrors(validationErrors config.ValidationErrors, format string) {
+	if format == "json" {
+		data, err := json.MarshalIndent(map[string]interface{}{"errors": validationErrors}, "", "  ")
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	fmt.Println("❌ Configuration validation failed:")
+	for _, err := range validationErrors {
+		fmt.Printf("  - %s\n", err.Error())
+	}
 }
 
 // startTUI initializes and runs the TUI application
@@ -261,61 +380,197 @@ func startTUI() {
 
 // createProjectViaCLI creates a project using CLI flags
 func createProjectViaCLI(cmd *cobra.Command, args []string) {
-	var projectName string
+	cfg := &config.ProjectConfig{}
+	errorFormat := errorFormatFlag(cmd)
+
+	// Start from a project definition file when one is given; flags override it
+	if fromFile, _ := cmd.Flags().GetString("from-file"); fromFile != "" {
+		loaded, err := config.LoadProjectConfig(fromFile)
+		if err != nil {
+			fmt.Printf("Error: failed to load project definition %s: %v\n", fromFile, err)
+			os.Exit(1)
+		}
+		cfg = loaded
+	}
+
 	if len(args) > 0 {
-		projectName = args[0]
-	} else {
+		cfg.ProjectName = args[0]
+	}
+	if cfg.ProjectName == "" {
 		fmt.Println("Error: project name is required")
 		fmt.Println("Usage: goback new [project-name]")
 		os.Exit(1)
 	}
+	projectName := cfg.ProjectName
+
+	// A monorepo gets the shared base; its services are added with 'goback add service'
+	flags := cmd.Flags()
+	if monorepo, _ := flags.GetBool("monorepo"); monorepo {
+		createPlatform(cmd, projectName)
+		return
+	}
+
+	// Flags override the project definition
+	if err := applyProjectFlags(cmd, cfg); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Copy a reference module or render a template pack instead of the built-in templates
+	if from, _ := flags.GetString("from"); from != "" {
+		createProjectFromModule(cfg, from, errorFormat)
+		return
+	}
+	if templateDir, _ := flags.GetString("template"); templateDir != "" {
+		createProjectFromPack(cfg, templateDir, errorFormat)
+		return
+	}
 
-	// Get flags
-	framework, _ := cmd.Flags().GetString("framework")
-	database, _ := cmd.Flags().GetString("database")
-	tool, _ := cmd.Flags().GetString("tool")
-	architecture, _ := cmd.Flags().GetString("architecture")
-	output, _ := cmd.Flags().GetString("output")
-	module, _ := cmd.Flags().GetString("module")
-	devops, _ := cmd.Flags().GetBool("devops")
-	devopsTools, _ := cmd.Flags().GetStringSlice("devops-tools")
-
-	// Set module and output dir if not provided
-	if module == "" {
-		module = "github.com/user/" + projectName
-	}
-	if output == "" {
-		output = "./" + projectName
-	}
-
-	// Create configuration
-	cfg := &config.ProjectConfig{
-		ProjectName:  projectName,
-		ModulePath:   module,
-		Description:  fmt.Sprintf("%s backend API", projectName),
-		OutputDir:    output,
-		Framework:    config.FrameworkChoice(framework),
-		Database:     config.DatabaseChoice(database),
-		Tool:         config.ToolChoice(tool),
-		Architecture: config.ArchitectureChoice(architecture),
-		DevOps: config.DevOpsConfig{
-			Enabled: devops,
-			Tools:   devopsTools,
-		},
+	if cfg.Kind == "" {
+		cfg.Kind = config.KindAPI
+	}
+
+	if cfg.OutputDir == "" {
+		cfg.OutputDir = "./" + projectName
+	}
+
+	// Ask for missing stack choices when a user is at the terminal; scripts keep the strict failure
+	if isInteractive() {
+		promptMissingChoices(cfg)
+	}
+
+	// Set module and description if not provided. A package of an existing
+	// module is imported under the path of its directory in that module.
+	if cfg.RepoMode == config.RepoPackage && cfg.ModulePath == "" {
+		if repo, err := project.DetectRepo(cfg.OutputDir); err == nil && repo.ModuleRoot != "" {
+			cfg.ModulePath, _ = repo.PackagePath(cfg.OutputDir)
+		}
+	}
+	if cfg.ModulePath == "" {
+		cfg.ModulePath = config.DefaultModulePath(projectName)
+	}
+	if cfg.Description == "" {
+		cfg.Description = fmt.Sprintf("%s backend API", projectName)
+	}
+	if cfg.License != "" && cfg.Author == "" {
+		cfg.Author = config.GetConfig().DefaultAuthor
+	}
+	if cfg.GoVersion == "" {
+		cfg.GoVersion = config.DetectGoVersion()
 	}
 
 	// Validate configuration
-	if validationErrors := config.ValidateProjectConfig(cfg); len(validationErrors) > 0 {
-		fmt.Println("❌ Configuration validation failed:")
-		for _, err := range validationErrors {
-			fmt.Printf("  - %s\n", err)
+	if validationErrors := config.ValidateNewProject(cfg); len(validationErrors) > 0 {
+		printValidationErrors(validationErrors, errorFormat)
+		if errorFormat != "json" && (cfg.Database == "" || cfg.Tool == "" || cfg.Architecture == "" || cfg.Kind.HasAPI() && cfg.Framework == "") {
+			fmt.Println("\nPlease provide all required flags: --database, --tool, --architecture, and --framework for API projects")
 		}
-		fmt.Println("\nPlease provide all required flags: --framework, --database, --tool, --architecture")
 		os.Exit(1)
 	}
+	if entity := cfg.SampleEntity(); entity != "" {
+		if _, err := generator.ParseResource(entity, cfg.SampleFieldSpec()); err != nil {
+			fmt.Printf("Error: invalid sample: %v\n", err)
+			os.Exit(1)
+		}
+	}
 
-	// Generate project
 	fmt.Printf("Creating project '%s'...\n", projectName)
+	generateProject(cfg)
+}
+
+// applyProjectFlags sets the stack choices and options given as flags on cfg, and
+// merges the template values. Commands may define only some of the flags.
+func applyProjectFlags(cmd *cobra.Command, cfg *config.ProjectConfig) error {
+	flags := cmd.Flags()
+	if flags.Changed("kind") {
+		kind, _ := flags.GetString("kind")
+		cfg.Kind = config.ProjectKind(kind)
+	}
+	if flags.Changed("framework") || cfg.Framework == "" {
+		framework, _ := flags.GetString("framework")
+		cfg.Framework = config.FrameworkChoice(framework)
+	}
+	// A framework given on the command line, or kept from the definition file
+	// for another kind, has nothing to serve without an HTTP API
+	if config.IsValidProjectKind(cfg.Kind) && !cfg.Kind.HasAPI() && cfg.Framework != "" && (flags.Changed("framework") || flags.Changed("kind")) {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring framework %s, %s projects have no HTTP API\n", string(cfg.Framework), string(cfg.Kind))
+		cfg.Framework = ""
+	}
+	if flags.Changed("database") || cfg.Database == "" {
+		database, _ := flags.GetString("database")
+		cfg.Database = config.DatabaseChoice(database)
+	}
+	if flags.Changed("tool") || cfg.Tool == "" {
+		tool, _ := flags.GetString("tool")
+		cfg.Tool = config.ToolChoice(tool)
+	}
+	if flags.Changed("architecture") || cfg.Architecture == "" {
+		architecture, _ := flags.GetString("architecture")
+		cfg.Architecture = config.ArchitectureChoice(architecture)
+	}
+	if flags.Changed("output") || cfg.OutputDir == "" {
+		cfg.OutputDir, _ = flags.GetString("output")
+	}
+	if flags.Changed("module") || cfg.ModulePath == "" {
+		cfg.ModulePath, _ = flags.GetString("module")
+	}
+	if flags.Changed("devops") {
+		cfg.DevOps.Enabled, _ = flags.GetBool("devops")
+	}
+	if flags.Changed("devops-tools") {
+		cfg.DevOps.Tools, _ = flags.GetStringSlice("devops-tools")
+	}
+	if flags.Changed("license") {
+		license, _ := flags.GetString("license")
+		cfg.License = config.LicenseChoice(license)
+	}
+	if flags.Changed("author") {
+		cfg.Author, _ = flags.GetString("author")
+	}
+	if flags.Changed("repo-mode") {
+		repoMode, _ := flags.GetString("repo-mode")
+		cfg.RepoMode = config.RepoMode(repoMode)
+	}
+	if flags.Changed("spdx-header") {
+		cfg.SPDXHeader, _ = flags.GetBool("spdx-header")
+	}
+	if flags.Changed("go-version") {
+		cfg.GoVersion, _ = flags.GetString("go-version")
+	}
+	if flags.Changed("sample") {
+		cfg.Sample, _ = flags.GetString("sample")
+	}
+	if flags.Changed("sample-fields") {
+		cfg.SampleFields, _ = flags.GetString("sample-fields")
+	}
+	return applyValueFlags(cmd, cfg)
+}
+
+// applyValueFlags merges the --values files and then the --set values into the template values
+func applyValueFlags(cmd *cobra.Command, cfg *config.ProjectConfig) error {
+	files, _ := cmd.Flags().GetStringArray("values")
+	for _, file := range files {
+		values, err := config.LoadValuesFile(file)
+		if err != nil {
+			return err
+		}
+		cfg.Values = config.MergeValues(cfg.Values, values)
+	}
+
+	sets, _ := cmd.Flags().GetStringArray("set")
+	for _, expr := range sets {
+		if cfg.Values == nil {
+			cfg.Values = map[string]interface{}{}
+		}
+		if err := config.SetValue(cfg.Values, expr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateProject runs the generator for cfg and records it in the recent projects list
+func generateProject(cfg *config.ProjectConfig) {
 	gen := generator.NewTemplateGenerator(cfg)
 
 	gen.SetProgressCallback(func(step int, message string) {
@@ -327,9 +582,31 @@ func createProjectViaCLI(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	fmt.Printf("\n✅ Project '%s' created successfully!\n", projectName)
-	fmt.Printf("Next steps:\n")
-	fmt.Printf("  cd %s\n", output)
-	fmt.Printf("  go mod tidy\n")
-	fmt.Printf("  go run main.go\n")
+	if err := config.AddRecentProject(cfg); err != nil && viper.GetBool("verbose") {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record recent project: %v\n", err)
+	}
+
+	fmt.Printf("\n✅ Project '%s' created successfully!\n\n", cfg.ProjectName)
+	fmt.Print(gen.Report().Text())
+
+	printMissingTools(cfg)
+}
+
+// printMissingTools reports the required tools of the new project that are not installed
+func printMissingTools(cfg *config.ProjectConfig) {
+	goVersion := ""
+	if mod, err := project.ReadGoMod(cfg.OutputDir); err == nil {
+		goVersion = mod.GoVersion
+	}
+
+	var missing []doctor.Result
+	for _, result := range doctor.Run(cfg, goVersion) {
+		if result.Required && !result.OK() {
+			missing = append(missing, result)
+		}
+	}
+	if len(missing) > 0 {
+		fmt.Printf("\nThe project needs tools that are not installed (run 'goback doctor' for details):\n")
+		printDoctorResults(missing, false)
+	}
 }
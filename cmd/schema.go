@@ -0,0 +1,62 @@
+// cmd/schema.go
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/NarmadaWeb/goback/pkg/config"
+
+	"github.com/spf13/cobra"
+)
+
+// schemaCmd prints the JSON Schema of a configuration file
+var schemaCmd = &cobra.Command{
+	Use:   "schema [project|app]",
+	Short: "Print the JSON Schema of a configuration file",
+	Long: `Prints the JSON Schema for GoBack configuration files.
+
+  project  Project definitions used with 'goback new --from-file'
+  app      The GoBack application configuration (~/.goback.yaml)
+
+Point your editor at the schema to get completion and validation, e.g. with
+the YAML language server:
+
+  # yaml-language-server: $schema=./goback-project.schema.json`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: config.GetSchemaKinds(),
+	Run: func(cmd *cobra.Command, args []string) {
+		schema := config.GetSchema(args[0])
+		if schema == nil {
+			fmt.Printf("Error: unknown schema '%s' (choose from: %s)\n",
+				args[0], strings.Join(config.GetSchemaKinds(), ", "))
+			os.Exit(1)
+		}
+
+		data, err := json.MarshalIndent(schema, "", "  ")
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		output, _ := cmd.Flags().GetString("file")
+		if output == "" {
+			fmt.Println(string(data))
+			return
+		}
+		if err := os.WriteFile(output, append(data, '\n'), 0644); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Schema written to %s\n", output)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+
+	schemaCmd.Flags().StringP("file", "o", "", "Write the schema to a file instead of stdout")
+}
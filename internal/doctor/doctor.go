@@ -0,0 +1,133 @@
+// internal/doctor/doctor.go
+
+package doctor
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/NarmadaWeb/goback/internal/utils"
+	"github.com/NarmadaWeb/goback/pkg/config"
+)
+
+// Check describes a binary that a generated project depends on
+type Check struct {
+	Name string
+	// Commands print the version of the binary; the first one that succeeds is used
+	Commands [][]string
+	// Reason names what in the project needs the binary
+	Reason   string
+	Install  string
+	Required bool
+}
+
+// Result is the outcome of a single check
+type Result struct {
+	Check
+	Found   bool
+	Version string
+	// Problem explains why a found binary is still not usable
+	Problem string
+}
+
+// OK reports whether the binary was found and is usable
+func (r Result) OK() bool {
+	return r.Found && r.Problem == ""
+}
+
+var versionPattern = regexp.MustCompile(`v?(\d+)\.(\d+)(\.\d+)?`)
+
+// Checks returns the binaries needed to work on a project with cfg. An empty
+// configuration returns only the binaries every project needs.
+func Checks(cfg *config.ProjectConfig) []Check {
+	checks := []Check{
+		{Name: "go", Commands: [][]string{{"go", "version"}}, Reason: "build and run the project", Install: "https://go.dev/dl/", Required: true},
+		{Name: "make", Commands: [][]string{{"make", "--version"}}, Reason: "Makefile targets", Install: "install make with your system package manager", Required: true},
+		{Name: "air", Commands: [][]string{{"air", "-v"}}, Reason: "make dev (live reload)", Install: "go install github.com/cosmtrek/air@latest"},
+		{Name: "golangci-lint", Commands: [][]string{{"golangci-lint", "--version"}}, Reason: "make lint", Install: "go install github.com/golangci/golangci-lint/cmd/golangci-lint@latest"},
+		{Name: "docker", Commands: [][]string{{"docker", "--version"}}, Reason: "Dockerfile, make docker-build", Install: "https://docs.docker.com/get-docker/"},
+		{Name: "docker compose", Commands: [][]string{{"docker", "compose", "version"}, {"docker-compose", "--version"}}, Reason: "docker-compose.yml", Install: "https://docs.docker.com/compose/install/"},
+	}
+
+	switch cfg.Tool {
+	case config.ToolSqlc:
+		checks = append(checks,
+			Check{Name: "sqlc", Commands: [][]string{{"sqlc", "version"}}, Reason: "make sqlc-generate", Install: "go install github.com/sqlc-dev/sqlc/cmd/sqlc@latest", Required: true},
+			migrateCheck(cfg))
+	case config.ToolSqlx:
+		checks = append(checks, migrateCheck(cfg))
+	}
+
+	for _, tool := range cfg.DevOps.Tools {
+		switch strings.ToLower(tool) {
+		case "helm":
+			checks = append(checks, Check{Name: "helm", Commands: [][]string{{"helm", "version", "--short"}}, Reason: "make helm-install", Install: "https://helm.sh/docs/intro/install/"})
+		case "terraform":
+			checks = append(checks, Check{Name: "terraform", Commands: [][]string{{"terraform", "version"}}, Reason: "make tf-plan, make tf-apply", Install: "https://developer.hashicorp.com/terraform/install"})
+		case "ansible":
+			checks = append(checks, Check{Name: "ansible-playbook", Commands: [][]string{{"ansible-playbook", "--version"}}, Reason: "make ansible-deploy", Install: "pipx install --include-deps ansible"})
+		case "kubernetes":
+			checks = append(checks, Check{Name: "kubectl", Commands: [][]string{{"kubectl", "version", "--client"}}, Reason: "make k8s-deploy", Install: "https://kubernetes.io/docs/tasks/tools/"})
+		}
+	}
+
+	return checks
+}
+
+// migrateCheck returns the golang-migrate check with the build tag of the database
+func migrateCheck(cfg *config.ProjectConfig) Check {
+	install := "go install github.com/golang-migrate/migrate/v4/cmd/migrate@latest"
+	switch cfg.Database {
+	case config.DatabasepostgresQL:
+		install = "go install -tags 'postgres' github.com/golang-migrate/migrate/v4/cmd/migrate@latest"
+	case config.DatabaseMySQL:
+		install = "go install -tags 'mysql' github.com/golang-migrate/migrate/v4/cmd/migrate@latest"
+	case config.DatabaseSQLite:
+		install = "go install -tags 'sqlite' github.com/golang-migrate/migrate/v4/cmd/migrate@latest"
+	}
+	return Check{Name: "migrate", Commands: [][]string{{"migrate", "-version"}}, Reason: "make migrate/up, make migrate/create", Install: install, Required: true}
+}
+
+// Run runs the checks for cfg. goVersion is the go directive of the project's
+// go.mod; when set, an older Go toolchain is reported as a problem.
+func Run(cfg *config.ProjectConfig, goVersion string) []Result {
+	checks := Checks(cfg)
+	results := make([]Result, 0, len(checks))
+	for _, check := range checks {
+		result := run(check)
+		if check.Name == "go" && result.Found && goVersion != "" && config.CompareGoVersions(result.Version, goVersion) < 0 {
+			result.Problem = fmt.Sprintf("the project requires go %s", goVersion)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// run runs the version commands of a check until one succeeds
+func run(check Check) Result {
+	result := Result{Check: check}
+	for _, command := range check.Commands {
+		if _, err := exec.LookPath(command[0]); err != nil {
+			continue
+		}
+		output, err := utils.RunCommandWithOutput(command[0], command[1:]...)
+		if err != nil {
+			continue
+		}
+		result.Found = true
+		result.Version = parseVersion(output)
+		return result
+	}
+	return result
+}
+
+// parseVersion extracts the version number from the output of a version command
+func parseVersion(output string) string {
+	if match := versionPattern.FindString(output); match != "" {
+		return strings.TrimPrefix(match, "v")
+	}
+	line, _, _ := strings.Cut(strings.TrimSpace(output), "\n")
+	return line
+}
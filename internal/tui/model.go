@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/NarmadaWeb/goback/internal/tui/models"
 	"github.com/NarmadaWeb/goback/pkg/config"
@@ -22,6 +23,7 @@ type AppState int
 const (
 	StateSplash AppState = iota
 	StateMainMenu
+	StateKindSelection
 	StateFrameworkSelection
 	StateDatabaseSelection
 	StateToolSelection
@@ -29,25 +31,29 @@ const (
 	StateDevOpsOptions
 	StateDevOpsToolsSelection
 	StateProjectDetails
+	StateRepoModeSelection
 	StateConfigReview
 	StateGeneration
 	StateProgress
 	StateSuccess
 	StateError
 	StateVersion
+	StateRecentProjects
 )
 
 // ConfigStep represents the current step in the configuration process
 type ConfigStep int
 
 const (
-	StepFramework ConfigStep = iota
+	StepKind ConfigStep = iota
+	StepFramework
 	StepDatabase
 	StepTool
 	StepArchitecture
 	StepDevOpsOptions
 	StepDevOpsTools
 	StepProjectDetails
+	StepRepoMode
 	StepReview
 )
 
@@ -62,6 +68,7 @@ type MainModel struct {
 	VersionModel  *models.VersionModel
 	ConfigModel   *models.ConfigModel
 	ProgressModel *models.ProgressModel
+	RecentModel   *models.RecentModel
 
 	// Shared state
 	Config *config.ProjectConfig
@@ -78,6 +85,7 @@ func NewMainModel() *MainModel {
 		VersionModel:  models.NewVersionModel(),
 		ConfigModel:   models.NewConfigModel(),
 		ProgressModel: models.NewProgressModel(),
+		RecentModel:   models.NewRecentModel(),
 	}
 }
 
@@ -119,14 +127,34 @@ func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.MenuModel.ResetSelected()
 			switch choice {
 			case "Start New Project":
-				m.State = StateFrameworkSelection
-				m.ConfigModel.SetStep(models.StepFramework)
+				m.State = StateKindSelection
+				m.ConfigModel.SetStep(models.StepKind)
+			case "Recent Projects":
+				m.State = StateRecentProjects
+				m.RecentModel.Load()
 			case "Version":
 				m.State = StateVersion
 			case "Exit":
 				return m, tea.Quit
 			}
 		}
+	case StateKindSelection:
+		var model tea.Model
+		model, cmd = m.ConfigModel.Update(msg)
+		if cm, ok := model.(*models.ConfigModel); ok {
+			m.ConfigModel = cm
+		}
+
+		if m.ConfigModel.IsStepComplete(models.StepKind) {
+			m.Config.Kind = m.ConfigModel.GetKindChoice()
+			if m.Config.Kind.HasAPI() {
+				m.State = StateFrameworkSelection
+			} else {
+				m.Config.Framework = ""
+				m.State = StateDatabaseSelection
+			}
+		}
+
 	case StateFrameworkSelection:
 		var model tea.Model
 		model, cmd = m.ConfigModel.Update(msg)
@@ -215,7 +243,27 @@ func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.Config.ModulePath = m.ConfigModel.GetModulePath()
 			m.Config.Description = m.ConfigModel.GetDescription()
 			m.Config.OutputDir = m.ConfigModel.GetOutputDir()
+			m.Config.RepoMode = m.ConfigModel.GetRepoMode()
+			if m.ConfigModel.Step == models.StepRepoMode {
+				m.State = StateRepoModeSelection
+			} else {
+				m.State = StateConfigReview
+			}
+		}
+
+	case StateRepoModeSelection:
+		var model tea.Model
+		model, cmd = m.ConfigModel.Update(msg)
+		if cm, ok := model.(*models.ConfigModel); ok {
+			m.ConfigModel = cm
+		}
+
+		if m.ConfigModel.IsStepComplete(models.StepRepoMode) {
+			m.Config.RepoMode = m.ConfigModel.GetRepoMode()
+			m.Config.ModulePath = m.ConfigModel.GetModulePath()
 			m.State = StateConfigReview
+		} else if m.ConfigModel.Step == models.StepProjectDetails {
+			m.State = StateProjectDetails
 		}
 
 	case StateConfigReview:
@@ -230,8 +278,8 @@ func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, m.ProgressModel.StartGeneration(m.Config)
 		}
 		if m.ConfigModel.IsCancelled() {
-			m.State = StateFrameworkSelection
-			m.ConfigModel.SetStep(models.StepFramework)
+			m.State = StateKindSelection
+			m.ConfigModel.SetStep(models.StepKind)
 		}
 
 	case StateGeneration, StateProgress:
@@ -279,6 +327,42 @@ func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.State = StateMainMenu
 			m.VersionModel.Reset()
 		}
+
+	case StateRecentProjects:
+		var model tea.Model
+		model, cmd = m.RecentModel.Update(msg)
+		if rm, ok := model.(*models.RecentModel); ok {
+			m.RecentModel = rm
+		}
+
+		if m.RecentModel.ShouldClose() {
+			m.State = StateMainMenu
+			m.RecentModel.Reset()
+			break
+		}
+
+		switch m.RecentModel.Action() {
+		case models.RecentActionRegenerate:
+			project := m.RecentModel.SelectedProject()
+			m.RecentModel.Reset()
+			if project.Config.Database == "" {
+				break // Entry was recorded without its configuration
+			}
+			cfg := project.Config
+			m.Config = &cfg
+			m.State = StateGeneration
+			return m, m.ProgressModel.StartGeneration(m.Config)
+		case models.RecentActionClone:
+			project := m.RecentModel.SelectedProject()
+			m.RecentModel.Reset()
+			if project.Config.Database == "" {
+				break
+			}
+			m.Config = config.CloneProjectConfig(&project.Config, project.Config.ProjectName+"-copy")
+			m.ConfigModel.LoadConfig(m.Config)
+			m.ConfigModel.SetStep(models.StepProjectDetails)
+			m.State = StateProjectDetails
+		}
 	}
 
 	// Handle global quit
@@ -300,13 +384,15 @@ func (m *MainModel) View() string {
 		view = m.SplashModel.View()
 	case StateMainMenu:
 		view = m.MenuModel.View()
-	case StateFrameworkSelection,
+	case StateKindSelection,
+		StateFrameworkSelection,
 		StateDatabaseSelection,
 		StateToolSelection,
 		StateArchitectureSelection,
 		StateDevOpsOptions,
 		StateDevOpsToolsSelection,
 		StateProjectDetails,
+		StateRepoModeSelection,
 		StateConfigReview:
 		view = m.ConfigModel.View()
 	case StateGeneration, StateProgress:
@@ -317,6 +403,8 @@ func (m *MainModel) View() string {
 		view = m.renderErrorView()
 	case StateVersion:
 		view = m.VersionModel.View()
+	case StateRecentProjects:
+		view = m.RecentModel.View()
 	default:
 		view = "Unknown state"
 	}
@@ -342,27 +430,44 @@ func (m *MainModel) renderSuccessView() string {
 		Padding(1).
 		Border(lipgloss.RoundedBorder())
 
+	framework := m.Config.Framework.String()
+	if !m.Config.Kind.HasAPI() {
+		framework = "none"
+	}
+
 	content := fmt.Sprintf(`
 ✅ Project created successfully!
 
 📁 Name: %s
 📂 Location: %s
+📦 Kind: %s
 🏗️  Framework: %s
 🗄️  Database: %s
 🏛️  Architecture: %s
 
 Next steps:
-  cd %s
-  go mod tidy
-  go run cmd/api/main.go
+  cd %s%s
+
+See GETTING_STARTED.md for the full report.
 
 Press '%s' to exit
-`, m.Config.ProjectName, m.Config.OutputDir, m.Config.Framework.String(),
-		m.Config.Database.String(), m.Config.Architecture.String(), m.Config.OutputDir, keyQ)
+`, m.Config.ProjectName, m.Config.OutputDir, m.Config.Kind.String(), framework,
+		m.Config.Database.String(), m.Config.Architecture.String(), m.Config.OutputDir, m.nextSteps(), keyQ)
 
 	return style.Render(content)
 }
 
+// nextSteps lists the follow-up commands from the generation report
+func (m *MainModel) nextSteps() string {
+	var steps strings.Builder
+	if report := m.ProgressModel.Report(); report != nil {
+		for _, step := range report.Steps {
+			steps.WriteString("\n  " + step.Command)
+		}
+	}
+	return steps.String()
+}
+
 // renderErrorView renders the error state view
 func (m *MainModel) renderErrorView() string {
 	style := lipgloss.NewStyle().
@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/NarmadaWeb/goback/internal/tui/styles"
 	"github.com/NarmadaWeb/goback/pkg/config"
+	"github.com/NarmadaWeb/goback/pkg/project"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
@@ -34,13 +36,15 @@ const (
 type ConfigStep int
 
 const (
-	StepFramework ConfigStep = iota
+	StepKind ConfigStep = iota
+	StepFramework
 	StepDatabase
 	StepTool
 	StepArchitecture
 	StepDevOpsOptions
 	StepDevOpsTools
 	StepProjectDetails
+	StepRepoMode
 	StepReview
 )
 
@@ -56,6 +60,7 @@ type ConfigModel struct {
 	inputs     []textinput.Model
 	focusIndex int
 
+	kind                config.ProjectKind
 	framework           config.FrameworkChoice
 	database            config.DatabaseChoice
 	tool                config.ToolChoice
@@ -63,14 +68,19 @@ type ConfigModel struct {
 	devopsEnabled       bool
 	devopsTools         []string
 	devopsToolsSelected map[string]bool
+	repoMode            config.RepoMode
+	repo                *project.Repo
 
-	validationErrors []string
+	validationErrors config.ValidationErrors
 }
 
+// inputFields are the configuration fields of the project detail inputs, in order
+var inputFields = []string{"project_name", "module_path", "description", "output_dir"}
+
 // NewConfigModel creates a new configuration model
 func NewConfigModel() *ConfigModel {
 	m := &ConfigModel{
-		Step:                StepFramework,
+		Step:                StepKind,
 		stepComplete:        make(map[ConfigStep]bool),
 		devopsToolsSelected: make(map[string]bool),
 		inputs:              make([]textinput.Model, 4),
@@ -146,7 +156,8 @@ func (m *ConfigModel) updateProjectDetailsInputs(msg tea.Msg) (tea.Model, tea.Cm
 			return m.goToPreviousStep()
 		case tea.KeyEnter:
 			if m.focusIndex == len(m.inputs)-1 {
-				if m.validateInputs() {
+				// Inside a module or git repository the inputs are validated once the repository mode is chosen
+				if m.outputInRepo() || m.validateInputs() {
 					m.completeStep()
 				}
 				return m, nil
@@ -178,7 +189,7 @@ func (m *ConfigModel) updateProjectDetailsInputs(msg tea.Msg) (tea.Model, tea.Cm
 	// Auto-update module path and output dir based on project name
 	if m.focusIndex == 0 {
 		projectName := m.inputs[0].Value()
-		m.inputs[1].SetValue("github.com/user/" + projectName)
+		m.inputs[1].SetValue(config.DefaultModulePath(projectName))
 		m.inputs[3].SetValue("./" + projectName)
 	}
 
@@ -210,7 +221,7 @@ func (m *ConfigModel) updateChoices(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 		case keyEsc, keyQ:
-			if m.Step == StepFramework {
+			if m.Step == StepKind {
 				m.canceled = true
 				return m, nil
 			}
@@ -227,6 +238,12 @@ func (m *ConfigModel) handleSelection() (tea.Model, tea.Cmd) {
 	selected := m.choices[m.cursor]
 
 	switch m.Step {
+	case StepKind:
+		m.kind = m.getKindFromString(selected)
+		if !m.kind.HasAPI() {
+			m.framework = "" // Only API projects use a web framework
+		}
+		m.completeStep()
 	case StepFramework:
 		m.framework = m.getFrameworkFromString(selected)
 		m.completeStep()
@@ -249,25 +266,55 @@ func (m *ConfigModel) handleSelection() (tea.Model, tea.Cmd) {
 		} else {
 			m.devopsToolsSelected[tool] = true
 		}
-		// Rebuild the tools slice to maintain order
-		m.devopsTools = []string{}
-		for _, choice := range []string{"Kubernetes", "Helm", "Terraform", "Ansible"} {
-			toolKey := m.getDevOpsToolFromString(choice)
-			if m.devopsToolsSelected[toolKey] {
-				m.devopsTools = append(m.devopsTools, toolKey)
+		m.syncDevOpsTools()
+	case StepRepoMode:
+		m.repoMode = m.getRepoModeFromString(selected)
+		if m.repoMode == config.RepoPackage {
+			if modulePath, err := m.repo.PackagePath(m.GetOutputDir()); err == nil {
+				m.inputs[1].SetValue(modulePath)
 			}
 		}
+		if m.validateInputs() {
+			m.completeStep()
+		} else {
+			delete(m.stepComplete, StepProjectDetails)
+			m.Step = StepProjectDetails
+			m.setupStep()
+		}
 	}
 	return m, nil
 }
 
+// outputInRepo reports whether the output directory is inside a Go module,
+// Go workspace or git repository, so a repository mode must be chosen
+func (m *ConfigModel) outputInRepo() bool {
+	repo, err := project.DetectRepo(m.GetOutputDir())
+	return err == nil && repo.Enclosed()
+}
+
+// syncDevOpsTools rebuilds the selected tools slice to maintain order
+func (m *ConfigModel) syncDevOpsTools() {
+	m.devopsTools = []string{}
+	for _, choice := range []string{"Kubernetes", "Helm", "Terraform", "Ansible"} {
+		toolKey := m.getDevOpsToolFromString(choice)
+		if m.devopsToolsSelected[toolKey] {
+			m.devopsTools = append(m.devopsTools, toolKey)
+		}
+	}
+}
+
 func (m *ConfigModel) goToPreviousStep() (tea.Model, tea.Cmd) {
 	delete(m.stepComplete, m.Step) // Mark current step as incomplete
 	prevStep := m.Step - 1
-	if prevStep < StepFramework {
+	delete(m.stepComplete, prevStep)
+	if prevStep < StepKind {
 		m.canceled = true
 		return m, nil
 	}
+	// Skip the framework if the project kind has no HTTP API
+	if prevStep == StepFramework && !m.kind.HasAPI() {
+		prevStep = StepKind
+	}
 	// Skip devops tools if devops was not enabled
 	if prevStep == StepDevOpsTools && !m.devopsEnabled {
 		prevStep = StepDevOpsOptions
@@ -280,6 +327,8 @@ func (m *ConfigModel) goToPreviousStep() (tea.Model, tea.Cmd) {
 // View renders the current configuration step
 func (m *ConfigModel) View() string {
 	switch m.Step {
+	case StepKind:
+		return m.renderKindSelection()
 	case StepFramework:
 		return m.renderFrameworkSelection()
 	case StepDatabase:
@@ -294,6 +343,8 @@ func (m *ConfigModel) View() string {
 		return m.renderDevOpsToolsSelection()
 	case StepProjectDetails:
 		return m.renderProjectDetails()
+	case StepRepoMode:
+		return m.renderRepoModeSelection()
 	case StepReview:
 		return m.renderConfigReview()
 	default:
@@ -301,33 +352,75 @@ func (m *ConfigModel) View() string {
 	}
 }
 
+// setupStep sets the choices of the current step, hiding the ones the organization policy forbids
 func (m *ConfigModel) setupStep() {
 	m.cursor = 0
+	policy := config.ActivePolicy()
 	switch m.Step {
+	case StepKind:
+		m.choices = []string{}
+		for _, kind := range config.AllowedChoices(policy, config.PolicyKinds, config.GetValidProjectKinds()) {
+			m.choices = append(m.choices, kind.String())
+		}
 	case StepFramework:
-		m.choices = []string{"Go Fiber", "Go Gin", "Go Chi", "Go Echo"}
+		m.choices = allowedChoices(config.PolicyFrameworks, []string{"Go Fiber", "Go Gin", "Go Chi", "Go Echo"},
+			func(s string) string { return string(m.getFrameworkFromString(s)) })
 	case StepDatabase:
-		m.choices = []string{"postgresQL", "MySQL", "SQLite"}
+		m.choices = allowedChoices(config.PolicyDatabases, []string{"postgresQL", "MySQL", "SQLite"},
+			func(s string) string { return string(m.getDatabaseFromString(s)) })
 	case StepTool:
-		m.choices = []string{"SQLX", "SQLC", "GORM"}
+		m.choices = allowedChoices(config.PolicyTools, []string{"SQLX", "SQLC", "GORM"},
+			func(s string) string { return string(m.getToolFromString(s)) })
 	case StepArchitecture:
-		m.choices = []string{
+		m.choices = allowedChoices(config.PolicyArchitectures, []string{
 			"Simple Architecture",
 			"Domain-Driven Design (DDD)",
 			"Clean Architecture",
 			"Hexagonal Architecture",
-		}
+		}, func(s string) string { return string(m.getArchitectureFromString(s)) })
 	case StepDevOpsOptions:
 		m.choices = []string{"Yes, use DevOps tools", "No, do not use DevOps tools"}
+		if policy.RequiresDevOps() {
+			m.choices = m.choices[:1]
+		}
 	case StepDevOpsTools:
-		m.choices = []string{"Kubernetes", "Helm", "Terraform", "Ansible"}
+		m.choices = allowedChoices(config.PolicyDevOpsTools, []string{"Kubernetes", "Helm", "Terraform", "Ansible"},
+			m.getDevOpsToolFromString)
+		for _, tool := range policy.RequiredDevOpsTools() {
+			m.devopsToolsSelected[tool] = true
+		}
+		m.syncDevOpsTools()
 	case StepProjectDetails:
 		m.choices = []string{} // No choices for input fields
+	case StepRepoMode:
+		m.choices = []string{}
+		m.repo, _ = project.DetectRepo(m.GetOutputDir())
+		if m.repo != nil {
+			for _, mode := range m.repo.Modes() {
+				m.choices = append(m.choices, mode.String())
+			}
+		}
 	case StepReview:
 		m.choices = []string{} // No choices for review
 	}
 }
 
+// allowedChoices hides the displayed choices the organization policy forbids;
+// toID maps a displayed choice to its ID
+func allowedChoices(category string, choices []string, toID func(string) string) []string {
+	policy := config.ActivePolicy()
+	var allowed []string
+	for _, choice := range choices {
+		if policy.Allows(category, toID(choice)) {
+			allowed = append(allowed, choice)
+		}
+	}
+	if len(allowed) == 0 {
+		return choices
+	}
+	return allowed
+}
+
 func (m *ConfigModel) initializeProjectDetailsDefaults() {
 	cwd, err := os.Getwd()
 	defaultProjectName := "my-backend-project"
@@ -335,7 +428,7 @@ func (m *ConfigModel) initializeProjectDetailsDefaults() {
 		defaultProjectName = filepath.Base(cwd)
 	}
 	m.inputs[0].SetValue(defaultProjectName)
-	m.inputs[1].SetValue("github.com/user/" + defaultProjectName)
+	m.inputs[1].SetValue(config.DefaultModulePath(defaultProjectName))
 	m.inputs[3].SetValue("./" + defaultProjectName)
 }
 
@@ -344,12 +437,16 @@ func (m *ConfigModel) validateInputs() bool {
 		ProjectName:  m.GetProjectName(),
 		ModulePath:   m.GetModulePath(),
 		OutputDir:    m.GetOutputDir(),
+		Kind:         m.kind,
 		Framework:    m.framework,
 		Database:     m.database,
 		Tool:         m.tool,
 		Architecture: m.architecture,
+		DevOps:       m.GetDevOpsConfig(),
+		License:      config.ActivePolicy().RequiredLicense(),
+		RepoMode:     m.repoMode,
 	}
-	m.validationErrors = config.ValidateProjectConfig(cfg)
+	m.validationErrors = config.ValidateNewProject(cfg)
 	return len(m.validationErrors) == 0
 }
 
@@ -369,8 +466,14 @@ func (m *ConfigModel) renderConfigReview() string {
 		addRow("📝", "Description", desc)
 	}
 	addRow("📂", "Output Directory", m.GetOutputDir())
+	if m.repoMode != "" {
+		addRow("🧩", "Repository Mode", m.repoMode.String())
+	}
 	content.WriteString("\n")
-	addRow("🏗️ ", "Framework", m.framework.String())
+	addRow("📦", "Kind", m.kind.String())
+	if m.kind.HasAPI() {
+		addRow("🏗️ ", "Framework", m.framework.String())
+	}
 	addRow("🗄️ ", "Database", m.database.String())
 	addRow("🔗", "Tool", m.tool.String())
 	addRow("🏛️ ", "Architecture", m.architecture.String())
@@ -401,17 +504,27 @@ func (m *ConfigModel) renderProjectDetails() string {
 	fmt.Fprintf(&b, "%s\n%s\n\n", title, subtitle)
 	labels := []string{"Project Name *", "Go Module Path *", "Description (Optional)", "Output Directory *"}
 
+	// Errors are shown next to their input, the others below the form
 	for i := range m.inputs {
 		b.WriteString(labels[i] + "\n")
 		b.WriteString(m.inputs[i].View())
+		for _, err := range m.validationErrors.ForField(inputFields[i]) {
+			b.WriteString("\n" + styles.ErrorStyle.Render("  ✗ "+err.Error()))
+		}
 		b.WriteString("\n\n")
 	}
 
-	if len(m.validationErrors) > 0 {
+	var others config.ValidationErrors
+	for _, err := range m.validationErrors {
+		if !slices.Contains(inputFields, err.Field) {
+			others = append(others, err)
+		}
+	}
+	if len(others) > 0 {
 		var errorContent strings.Builder
 		errorContent.WriteString(styles.ErrorTitleStyle.Render("Validation Errors:"))
-		for _, err := range m.validationErrors {
-			errorContent.WriteString("\n" + styles.ErrorStyle.Render("• "+err))
+		for _, err := range others {
+			errorContent.WriteString("\n" + styles.ErrorStyle.Render("• "+err.Error()))
 		}
 		b.WriteString("\n" + errorContent.String() + "\n")
 	}
@@ -439,6 +552,10 @@ func (m *ConfigModel) renderGenericChoiceView(titleText, subtitleText string) st
 	return lipgloss.JoinVertical(lipgloss.Left, title, subtitle, "\n", options.String(), help)
 }
 
+func (m *ConfigModel) renderKindSelection() string {
+	return m.renderGenericChoiceView("📦 Select Project Kind", "The kind of program your project builds.")
+}
+
 func (m *ConfigModel) renderFrameworkSelection() string {
 	return m.renderGenericChoiceView("🏗️  Select Backend Framework", "The web framework to be used for your project.")
 }
@@ -455,6 +572,11 @@ func (m *ConfigModel) renderArchitectureSelection() string {
 	return m.renderGenericChoiceView("🏛️  Select Project Architecture", "The architectural pattern for your project structure.")
 }
 
+func (m *ConfigModel) renderRepoModeSelection() string {
+	return m.renderGenericChoiceView("🧩 Select Repository Mode",
+		"The output directory is inside an existing Go module or git repository.")
+}
+
 func (m *ConfigModel) renderDevOpsOptions() string {
 	return m.renderGenericChoiceView("🚀 DevOps Configuration", "Do you want to add DevOps configuration files?")
 }
@@ -489,10 +611,19 @@ func (m *ConfigModel) renderDevOpsToolsSelection() string {
 func (m *ConfigModel) completeStep() {
 	m.stepComplete[m.Step] = true
 	nextStep := m.Step + 1
+	// Skip the framework step if the project kind has no HTTP API
+	if nextStep == StepFramework && !m.kind.HasAPI() {
+		nextStep = StepDatabase
+	}
 	// Skip devops tools step if devops is not enabled
 	if nextStep == StepDevOpsTools && !m.devopsEnabled {
 		nextStep = StepProjectDetails
 	}
+	// Skip the repository mode outside of modules and git repositories
+	if nextStep == StepRepoMode && !m.outputInRepo() {
+		m.repoMode = ""
+		nextStep = StepReview
+	}
 	if nextStep > StepReview {
 		nextStep = StepReview
 	}
@@ -501,6 +632,15 @@ func (m *ConfigModel) completeStep() {
 }
 
 // Getters and other helper functions
+func (m *ConfigModel) getKindFromString(s string) config.ProjectKind {
+	for _, kind := range config.GetValidProjectKinds() {
+		if kind.String() == s {
+			return kind
+		}
+	}
+	return ""
+}
+
 func (m *ConfigModel) getFrameworkFromString(s string) config.FrameworkChoice {
 	switch s {
 	case "Go Fiber":
@@ -557,10 +697,45 @@ func (m *ConfigModel) getArchitectureFromString(s string) config.ArchitectureCho
 	}
 }
 
+func (m *ConfigModel) getRepoModeFromString(s string) config.RepoMode {
+	for _, mode := range config.GetValidRepoModes() {
+		if mode.String() == s {
+			return mode
+		}
+	}
+	return ""
+}
+
 func (m *ConfigModel) getDevOpsToolFromString(s string) string {
 	return strings.ToLower(s)
 }
 
+// LoadConfig pre-fills the choices and project details from an existing configuration
+func (m *ConfigModel) LoadConfig(cfg *config.ProjectConfig) {
+	m.kind = cfg.Kind
+	m.framework = cfg.Framework
+	m.database = cfg.Database
+	m.tool = cfg.Tool
+	m.architecture = cfg.Architecture
+	m.devopsEnabled = cfg.DevOps.Enabled
+	m.devopsTools = append([]string{}, cfg.DevOps.Tools...)
+	m.repoMode = cfg.RepoMode
+	m.devopsToolsSelected = make(map[string]bool)
+	for _, tool := range m.devopsTools {
+		m.devopsToolsSelected[tool] = true
+	}
+
+	m.inputs[0].SetValue(cfg.ProjectName)
+	m.inputs[1].SetValue(cfg.ModulePath)
+	m.inputs[2].SetValue(cfg.Description)
+	m.inputs[3].SetValue(cfg.OutputDir)
+
+	m.stepComplete = make(map[ConfigStep]bool)
+	m.confirmed = false
+	m.canceled = false
+	m.validationErrors = nil
+}
+
 func (m *ConfigModel) SetStep(step ConfigStep) {
 	m.Step = step
 	m.setupStep()
@@ -569,11 +744,13 @@ func (m *ConfigModel) SetStep(step ConfigStep) {
 func (m *ConfigModel) IsStepComplete(step ConfigStep) bool              { return m.stepComplete[step] }
 func (m *ConfigModel) IsConfirmed() bool                                { return m.confirmed }
 func (m *ConfigModel) IsCancelled() bool                                { return m.canceled }
+func (m *ConfigModel) GetKindChoice() config.ProjectKind                { return m.kind }
 func (m *ConfigModel) GetFrameworkChoice() config.FrameworkChoice       { return m.framework }
 func (m *ConfigModel) GetDatabaseChoice() config.DatabaseChoice         { return m.database }
 func (m *ConfigModel) GetToolChoice() config.ToolChoice                 { return m.tool }
 func (m *ConfigModel) GetArchitectureChoice() config.ArchitectureChoice { return m.architecture }
 func (m *ConfigModel) GetDevOpsEnabled() bool                           { return m.devopsEnabled }
+func (m *ConfigModel) GetRepoMode() config.RepoMode                     { return m.repoMode }
 func (m *ConfigModel) GetDevOpsConfig() config.DevOpsConfig {
 	cfg := config.DevOpsConfig{
 		Enabled: m.devopsEnabled,
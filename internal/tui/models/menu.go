@@ -17,6 +17,7 @@ func NewMenuModel() *MenuModel {
 	return &MenuModel{
 		choices: []string{
 			"Start New Project",
+			"Recent Projects",
 			"Version",
 			"Exit",
 		},
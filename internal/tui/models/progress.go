@@ -48,11 +48,15 @@ func NewProgressModel() *ProgressModel {
 			"Validating configuration...",
 			"Creating project structure...",
 			"Generating framework files...",
+			"Generating worker and CLI files...",
 			"Setting up database configuration...",
 			"Applying architecture pattern...",
 			"Installing dependencies...",
+			"Generating sample resource...",
 			"Generating DevOps files...",
 			"Finalizing project...",
+			"Writing getting started guide...",
+			"Recording project metadata...",
 		},
 	}
 }
@@ -207,12 +211,14 @@ func (m *ProgressModel) renderSuccess() string {
 	projectInfo := fmt.Sprintf(`
 📁 Project Name: %s
 📂 Location: %s
+📦 Kind: %s
 🚀 Framework: %s
 🗄️  Database: %s
 ⚙️  ORM: %s
 🏗️  Architecture: %s`,
 		m.config.ProjectName,
 		m.config.OutputDir,
+		m.config.Kind,
 		m.config.Framework,
 		m.config.Database,
 		m.config.Tool,
@@ -224,13 +230,11 @@ func (m *ProgressModel) renderSuccess() string {
 		projectInfo += devopsInfo
 	}
 
-	nextSteps := `
-Next Steps:
-1. cd ` + m.config.OutputDir + `
-2. go mod tidy
-3. go run main.go
-
-Your project is ready to use!`
+	nextSteps := "Next Steps:\n1. cd " + m.config.OutputDir
+	for i, step := range m.generator.Report().Steps {
+		nextSteps += fmt.Sprintf("\n%d. %s", i+2, step.Command)
+	}
+	nextSteps += "\n\nSee GETTING_STARTED.md for the full report."
 
 	content := lipgloss.JoinVertical(lipgloss.Center,
 		title,
@@ -279,6 +283,12 @@ func (m *ProgressModel) renderError() string {
 func (m *ProgressModel) StartGeneration(config *config.ProjectConfig) tea.Cmd {
 	m.config = config
 	m.startTime = time.Now()
+	m.finished = false
+	m.success = false
+	m.error = nil
+	m.progress = 0
+	m.stepIndex = 0
+	m.currentMsg = ""
 	m.generator = generator.NewTemplateGenerator(config)
 
 	return tea.Batch(
@@ -313,6 +323,9 @@ func (m *ProgressModel) runGeneration() tea.Cmd {
 			}
 		}
 
+		// Recording the project is best effort and must not fail the generation
+		_ = config.AddRecentProject(m.config)
+
 		return generationCompleteMsg{
 			success: true,
 			err:     nil,
@@ -320,6 +333,14 @@ func (m *ProgressModel) runGeneration() tea.Cmd {
 	}
 }
 
+// Report returns the report of the last generation
+func (m *ProgressModel) Report() *generator.Report {
+	if m.generator == nil {
+		return nil
+	}
+	return m.generator.Report()
+}
+
 // Getter methods for state checking
 func (m *ProgressModel) IsFinished() bool {
 	return m.finished
@@ -0,0 +1,176 @@
+// internal/tui/models/recent.go
+
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NarmadaWeb/goback/internal/tui/styles"
+	"github.com/NarmadaWeb/goback/pkg/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Actions available for a recent project
+const (
+	RecentActionRegenerate = "Regenerate with the same configuration"
+	RecentActionClone      = "Clone configuration under a new name"
+	RecentActionBack       = "Back"
+)
+
+// RecentModel lists recently generated projects and the actions for them
+type RecentModel struct {
+	projects []config.RecentProject
+	cursor   int
+	selected int
+	actions  []string
+	action   string
+	closed   bool
+}
+
+// NewRecentModel creates a new recent projects model
+func NewRecentModel() *RecentModel {
+	return &RecentModel{
+		selected: -1,
+		actions:  []string{RecentActionRegenerate, RecentActionClone, RecentActionBack},
+	}
+}
+
+// Load refreshes the list of recent projects
+func (m *RecentModel) Load() {
+	m.projects = config.GetRecentProjects()
+	m.Reset()
+}
+
+// Reset clears the current selection and action
+func (m *RecentModel) Reset() {
+	m.cursor = 0
+	m.selected = -1
+	m.action = ""
+	m.closed = false
+}
+
+func (m *RecentModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m *RecentModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	limit := len(m.projects)
+	if m.selected >= 0 {
+		limit = len(m.actions)
+	}
+
+	switch keyMsg.String() {
+	case keyUp, keyK:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case keyDown, keyJ:
+		if m.cursor < limit-1 {
+			m.cursor++
+		}
+	case keyEnter, keySpace:
+		if limit == 0 {
+			m.closed = true
+			return m, nil
+		}
+		if m.selected < 0 {
+			m.selected = m.cursor
+			m.cursor = 0
+			return m, nil
+		}
+		if m.actions[m.cursor] == RecentActionBack {
+			m.cursor = m.selected
+			m.selected = -1
+			return m, nil
+		}
+		m.action = m.actions[m.cursor]
+	case keyEsc, keyQ:
+		if m.selected >= 0 {
+			m.cursor = m.selected
+			m.selected = -1
+			return m, nil
+		}
+		m.closed = true
+	case keyCtrlC:
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m *RecentModel) View() string {
+	title := styles.TitleStyle.Render("🕘 Recent Projects")
+
+	if len(m.projects) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left,
+			title,
+			styles.SubtitleStyle.Render("No projects have been generated yet."),
+			styles.HelpStyle.Render("enter/esc: back"),
+		)
+	}
+
+	if m.selected >= 0 {
+		project := m.projects[m.selected]
+		subtitle := styles.SubtitleStyle.Render(fmt.Sprintf("%s — %s", project.Config.ProjectName, project.Path))
+
+		var options strings.Builder
+		for i, action := range m.actions {
+			cursor := "  "
+			if i == m.cursor {
+				cursor = "> "
+				action = styles.SelectedStyle.Render(action)
+			} else {
+				action = styles.OptionStyle.Render(action)
+			}
+			options.WriteString(cursor + action + "\n\n")
+		}
+		help := styles.HelpStyle.Render("↑/↓: navigate • enter: select • esc: back • ctrl+c: quit")
+		return lipgloss.JoinVertical(lipgloss.Left, title, subtitle, "\n", options.String(), help)
+	}
+
+	subtitle := styles.SubtitleStyle.Render("Select a project to regenerate or clone its configuration.")
+	var options strings.Builder
+	for i, project := range m.projects {
+		cursor := "  "
+		name := project.Config.ProjectName
+		if name == "" {
+			name = project.Path
+		}
+		if i == m.cursor {
+			cursor = "> "
+			name = styles.SelectedStyle.Render(name)
+		} else {
+			name = styles.OptionStyle.Render(name)
+		}
+		line := name
+		if project.Config.Framework != "" {
+			line += "  " + styles.MutedStyle.Render(project.Summary())
+		}
+		options.WriteString(cursor + line + "\n")
+		options.WriteString("    " + styles.MutedStyle.Render(project.Path) + "\n\n")
+	}
+	help := styles.HelpStyle.Render("↑/↓: navigate • enter: select • esc: back • ctrl+c: quit")
+	return lipgloss.JoinVertical(lipgloss.Left, title, subtitle, "\n", options.String(), help)
+}
+
+// Action returns the chosen action for the selected project, if any
+func (m *RecentModel) Action() string {
+	return m.action
+}
+
+// SelectedProject returns the project the action applies to
+func (m *RecentModel) SelectedProject() config.RecentProject {
+	return m.projects[m.selected]
+}
+
+// ShouldClose reports whether the user left the recent projects view
+func (m *RecentModel) ShouldClose() bool {
+	return m.closed
+}
@@ -4,11 +4,15 @@ package utils
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"go/token"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+
+	"golang.org/x/mod/module"
 )
 
 // RunCommand executes a shell command and returns an error if it fails
@@ -330,7 +334,8 @@ func SplitPath(path string) (string, string) {
 	return filepath.Split(path)
 }
 
-// ValidateProjectName validates a project name
+// ValidateProjectName checks that a project name can be used as the binary name
+// and gives a valid Go package name
 func ValidateProjectName(name string) error {
 	if name == "" {
 		return fmt.Errorf("project name cannot be empty")
@@ -344,18 +349,53 @@ func ValidateProjectName(name string) error {
 		return fmt.Errorf("project name cannot contain path separators")
 	}
 
+	if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "-") {
+		return fmt.Errorf("project name cannot start with '.' or '-'")
+	}
+
+	for _, r := range name {
+		if !isNameChar(r) {
+			return fmt.Errorf("project name cannot contain %q, use letters, digits, '-', '_' and '.'", r)
+		}
+	}
+
+	pkg := PackageName(name)
+	if token.IsKeyword(pkg) {
+		return fmt.Errorf("project name gives the Go package name %s, which is a keyword", pkg)
+	}
+	if !token.IsIdentifier(pkg) {
+		return fmt.Errorf("project name must start with a letter to give a valid Go package name")
+	}
+
 	return nil
 }
 
-// ValidateModulePath validates a Go module path
+// PackageName returns the Go package name for a project name: lower case,
+// without '-', '_' and '.'
+func PackageName(name string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", "_", "", ".", "").Replace(name))
+}
+
+// isNameChar reports whether r may be used in a binary name on every platform
+func isNameChar(r rune) bool {
+	return 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' || r == '-' || r == '_' || r == '.'
+}
+
+// ValidateModulePath validates a Go module path with the rules of 'go mod init'
 func ValidateModulePath(path string) error {
 	if path == "" {
 		return fmt.Errorf("module path cannot be empty")
 	}
 
-	// Basic validation - should contain at least one slash
-	if !strings.Contains(path, "/") {
-		return fmt.Errorf("module path should be in format 'domain.com/username/project'")
+	if err := module.CheckImportPath(path); err != nil {
+		var pathErr *module.InvalidPathError
+		if errors.As(err, &pathErr) {
+			return fmt.Errorf("invalid module path: %v", pathErr.Err)
+		}
+		return err
+	}
+	if _, _, ok := module.SplitPathVersion(path); !ok {
+		return fmt.Errorf("invalid module path: major version suffixes must be in the form of /vN and are only allowed for v2 or later")
 	}
 
 	return nil
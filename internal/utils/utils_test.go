@@ -0,0 +1,76 @@
+// internal/utils/utils_test.go
+
+package utils
+
+import "testing"
+
+func TestValidateProjectName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"api", false},
+		{"billing-service", false},
+		{"billing_service", false},
+		{"v2.api", false},
+		{"Billing", false},
+		{"", true},
+		{"my app", true},
+		{"acme/api", true},
+		{`acme\api`, true},
+		{".hidden", true},
+		{"-flag", true},
+		{"api!", true},
+		{"café", true},
+		{"2fa", true},
+		{"func", true},
+		{"go-to", true},
+	}
+	for _, tt := range tests {
+		if err := ValidateProjectName(tt.name); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateProjectName(%q) error = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	tests := []struct {
+		name, want string
+	}{
+		{"api", "api"},
+		{"billing-service", "billingservice"},
+		{"Billing_Service", "billingservice"},
+		{"v2.api", "v2api"},
+	}
+	for _, tt := range tests {
+		if got := PackageName(tt.name); got != tt.want {
+			t.Errorf("PackageName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestValidateModulePath(t *testing.T) {
+	tests := []struct {
+		path    string
+		wantErr bool
+	}{
+		{"github.com/acme/api", false},
+		{"example.com/api/v2", false},
+		{"api", false},
+		{"git.acme.io/team/billing-service", false},
+		{"", true},
+		{"github.com/acme/my api", true},
+		{"/github.com/acme/api", true},
+		{"github.com/acme/api/", true},
+		{"github.com//api", true},
+		{"github.com/acme/../api", true},
+		{"example.com/api/v1", true},
+		{"example.com/api/v0", true},
+		{"github.com/acme/api@v1", true},
+	}
+	for _, tt := range tests {
+		if err := ValidateModulePath(tt.path); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateModulePath(%q) error = %v, want error %v", tt.path, err, tt.wantErr)
+		}
+	}
+}
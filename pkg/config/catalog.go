@@ -0,0 +1,103 @@
+// pkg/config/catalog.go
+
+package config
+
+// Catalog describes every choice offered by GoBack and how they combine, so
+// that other tools can build project forms without hardcoding the options.
+type Catalog struct {
+	Kinds         []ChoiceInfo  `json:"kinds" yaml:"kinds"`
+	Frameworks    []ChoiceInfo  `json:"frameworks" yaml:"frameworks"`
+	Databases     []ChoiceInfo  `json:"databases" yaml:"databases"`
+	Tools         []ChoiceInfo  `json:"tools" yaml:"tools"`
+	Architectures []ChoiceInfo  `json:"architectures" yaml:"architectures"`
+	DevOpsTools   []ChoiceInfo  `json:"devops_tools" yaml:"devops_tools"`
+	Licenses      []ChoiceInfo  `json:"licenses" yaml:"licenses"`
+	Compatibility Compatibility `json:"compatibility" yaml:"compatibility"`
+}
+
+// ChoiceInfo describes a single choice
+type ChoiceInfo struct {
+	ID           string          `json:"id" yaml:"id"`
+	Name         string          `json:"name" yaml:"name"`
+	Description  string          `json:"description" yaml:"description"`
+	Capabilities map[string]bool `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
+}
+
+// Compatibility describes which choices can be combined
+type Compatibility struct {
+	// Combinations lists every supported framework, database and tool combination
+	Combinations []Combination `json:"combinations" yaml:"combinations"`
+	// RecommendedTools maps each database to the tool recommended for it
+	RecommendedTools map[string]string `json:"recommended_tools" yaml:"recommended_tools"`
+}
+
+// Combination is a supported framework, database and tool combination
+type Combination struct {
+	Framework string `json:"framework" yaml:"framework"`
+	Database  string `json:"database" yaml:"database"`
+	Tool      string `json:"tool" yaml:"tool"`
+}
+
+// GetCatalog returns the catalog of all choices
+func GetCatalog() *Catalog {
+	catalog := &Catalog{
+		Compatibility: Compatibility{RecommendedTools: map[string]string{}},
+	}
+
+	for _, k := range GetValidProjectKinds() {
+		catalog.Kinds = append(catalog.Kinds, ChoiceInfo{
+			ID:           string(k),
+			Name:         k.String(),
+			Description:  k.Description(),
+			Capabilities: map[string]bool{"needs_framework": k.HasAPI()},
+		})
+	}
+	for _, f := range GetValidFrameworks() {
+		catalog.Frameworks = append(catalog.Frameworks, ChoiceInfo{ID: string(f), Name: f.String(), Description: f.Description()})
+	}
+	for _, d := range GetValidDatabases() {
+		catalog.Databases = append(catalog.Databases, ChoiceInfo{
+			ID:          string(d),
+			Name:        d.String(),
+			Description: d.Description(),
+			Capabilities: map[string]bool{
+				"requires_server":    d.RequiresServer(),
+				"supports_relations": d.SupportsRelations(),
+			},
+		})
+		catalog.Compatibility.RecommendedTools[string(d)] = string(GetRecommendedTool(d))
+	}
+	for _, t := range GetValidTools() {
+		catalog.Tools = append(catalog.Tools, ChoiceInfo{
+			ID:          string(t),
+			Name:        t.String(),
+			Description: t.Description(),
+			Capabilities: map[string]bool{
+				"has_migrations":      t.HasMigrations(),
+				"has_code_generation": t.HasCodeGeneration(),
+			},
+		})
+	}
+	for _, a := range GetValidArchitectures() {
+		catalog.Architectures = append(catalog.Architectures, ChoiceInfo{ID: string(a), Name: a.String(), Description: a.Description()})
+	}
+	for _, tool := range GetValidDevOpsTools() {
+		catalog.DevOpsTools = append(catalog.DevOpsTools, ChoiceInfo{ID: tool, Name: tool, Description: GetDevOpsToolDescription(tool)})
+	}
+	for _, l := range GetValidLicenses() {
+		catalog.Licenses = append(catalog.Licenses, ChoiceInfo{ID: string(l), Name: l.String(), Description: l.Description()})
+	}
+
+	for _, f := range GetValidFrameworks() {
+		for _, d := range GetValidDatabases() {
+			for _, t := range GetValidTools() {
+				if IsCompatible(f, d, t) {
+					catalog.Compatibility.Combinations = append(catalog.Compatibility.Combinations,
+						Combination{Framework: string(f), Database: string(d), Tool: string(t)})
+				}
+			}
+		}
+	}
+
+	return catalog
+}
@@ -3,22 +3,29 @@
 package config
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
 )
 
 // AppConfig represents application-level configuration
 type AppConfig struct {
-	DefaultOutputDir    string `json:"default_output_dir" yaml:"default_output_dir"`
-	DefaultModulePrefix string `json:"default_module_prefix" yaml:"default_module_prefix"`
-	DefaultAuthor       string `json:"default_author" yaml:"default_author"`
-	AnimationSpeed      int    `json:"animation_speed" yaml:"animation_speed"`
-	ShowSplashScreen    bool   `json:"show_splash_screen" yaml:"show_splash_screen"`
-	AutoSave            bool   `json:"auto_save" yaml:"auto_save"`
-	Theme               string `json:"theme" yaml:"theme"`
+	DefaultOutputDir    string `json:"default_output_dir" yaml:"default_output_dir" mapstructure:"default_output_dir"`
+	DefaultModulePrefix string `json:"default_module_prefix" yaml:"default_module_prefix" mapstructure:"default_module_prefix"`
+	DefaultAuthor       string `json:"default_author" yaml:"default_author" mapstructure:"default_author"`
+	AnimationSpeed      int    `json:"animation_speed" yaml:"animation_speed" mapstructure:"animation_speed"`
+	ShowSplashScreen    bool   `json:"show_splash_screen" yaml:"show_splash_screen" mapstructure:"show_splash_screen"`
+	AutoSave            bool   `json:"auto_save" yaml:"auto_save" mapstructure:"auto_save"`
+	Theme               string `json:"theme" yaml:"theme" mapstructure:"theme"`
+	Policy              string `json:"policy,omitempty" yaml:"policy,omitempty" mapstructure:"policy"`
 }
 
 // Note: ProjectConfig and DevOpsConfig are defined in types.go
@@ -60,6 +67,8 @@ func NewProjectConfig() *ProjectConfig {
 		Database:     DatabasepostgresQL, // Default to postgresQL
 		Tool:         ToolSqlx,           // Default to SQLX
 		Architecture: ArchitectureSimple, // Default to Simple
+		GoVersion:    DetectGoVersion(),
+		License:      ActivePolicy().RequiredLicense(),
 		DevOps: DevOpsConfig{
 			Enabled: false,
 			Tools:   []string{},
@@ -105,9 +114,19 @@ func createDefaultConfigFile() {
 
 	configPath := filepath.Join(configHome, ".goback.yaml")
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		// Create default config file
+		// Create default config file with the application settings only, not
+		// the flags bound to the global viper instance
+		defaults := viper.New()
+		defaults.Set("default_output_dir", defaultConfig.DefaultOutputDir)
+		defaults.Set("default_module_prefix", defaultConfig.DefaultModulePrefix)
+		defaults.Set("default_author", defaultConfig.DefaultAuthor)
+		defaults.Set("animation_speed", defaultConfig.AnimationSpeed)
+		defaults.Set("show_splash_screen", defaultConfig.ShowSplashScreen)
+		defaults.Set("auto_save", defaultConfig.AutoSave)
+		defaults.Set("theme", defaultConfig.Theme)
+		defaults.SetConfigFile(configPath)
+		_ = defaults.WriteConfig()
 		viper.SetConfigFile(configPath)
-		_ = viper.WriteConfig()
 	}
 }
 
@@ -143,7 +162,12 @@ func LoadProjectConfig(filepath string) (*ProjectConfig, error) {
 	}
 
 	var cfg ProjectConfig
-	if err := v.Unmarshal(&cfg); err != nil {
+	decodeHook := viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeHookFunc(time.RFC3339),
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+	))
+	if err := v.Unmarshal(&cfg, decodeHook); err != nil {
 		return nil, err
 	}
 
@@ -157,11 +181,16 @@ func SaveProjectConfig(cfg *ProjectConfig, filepath string) error {
 	v.Set("module_path", cfg.ModulePath)
 	v.Set("description", cfg.Description)
 	v.Set("output_dir", cfg.OutputDir)
+	v.Set("kind", cfg.Kind)
 	v.Set("framework", cfg.Framework)
 	v.Set("database", cfg.Database)
-	v.Set("tools", cfg.Tool)
+	v.Set("tool", cfg.Tool)
 	v.Set("architecture", cfg.Architecture)
 	v.Set("devops", cfg.DevOps)
+	v.Set("go_version", cfg.GoVersion)
+	v.Set("sample", cfg.Sample)
+	v.Set("sample_fields", cfg.SampleFields)
+	v.Set("values", cfg.Values)
 	v.Set("created_at", cfg.CreatedAt)
 	v.Set("updated_at", cfg.UpdatedAt)
 
@@ -186,37 +215,148 @@ func GetConfigDir() (string, error) {
 	return configDir, nil
 }
 
-// GetRecentProjects returns list of recently created projects
-func GetRecentProjects() []string {
-	projects := viper.GetStringSlice("recent_projects")
-	if projects == nil {
-		return []string{}
+// RecentProject describes a project previously generated by GoBack
+type RecentProject struct {
+	Path        string        `json:"path" yaml:"path"`
+	Config      ProjectConfig `json:"config" yaml:"config"`
+	GeneratedAt time.Time     `json:"generated_at" yaml:"generated_at"`
+}
+
+// Summary returns a short, single-line description of the project's stack
+func (r RecentProject) Summary() string {
+	return fmt.Sprintf("%s / %s / %s / %s",
+		r.Config.Framework, r.Config.Database, r.Config.Tool, r.Config.Architecture)
+}
+
+// recentProjectsFile keeps the recent projects under GetConfigDir, apart from
+// the settings in the application config file
+const recentProjectsFile = "recent.yaml"
+
+// recentProjectsStore returns a viper instance that reads and writes only the
+// recent projects file
+func recentProjectsStore() (*viper.Viper, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+
+	store := viper.New()
+	store.SetConfigFile(filepath.Join(dir, recentProjectsFile))
+	if err := store.ReadInConfig(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	return store, nil
+}
+
+// GetRecentProjects returns list of recently created projects, newest first
+func GetRecentProjects() []RecentProject {
+	var raw []interface{}
+	if store, err := recentProjectsStore(); err == nil && store.IsSet("recent_projects") {
+		raw, _ = store.Get("recent_projects").([]interface{})
+	} else {
+		// Older versions kept the list in the application config file
+		raw, _ = viper.Get("recent_projects").([]interface{})
+	}
+	if len(raw) == 0 {
+		return []RecentProject{}
+	}
+
+	projects := make([]RecentProject, 0, len(raw))
+	for _, item := range raw {
+		// Older versions stored only the project path
+		if path, isPath := item.(string); isPath {
+			projects = append(projects, RecentProject{Path: path})
+			continue
+		}
+
+		// Round-trip through JSON so the struct tags drive decoding
+		data, err := json.Marshal(item)
+		if err != nil {
+			continue
+		}
+		var project RecentProject
+		if err := json.Unmarshal(data, &project); err != nil {
+			continue
+		}
+		projects = append(projects, project)
 	}
 	return projects
 }
 
-// AddRecentProject adds a project to recent projects list
-func AddRecentProject(projectPath string) error {
+// AddRecentProject adds a generated project to recent projects list
+func AddRecentProject(cfg *ProjectConfig) error {
+	projectPath, err := filepath.Abs(cfg.OutputDir)
+	if err != nil {
+		return err
+	}
+
+	recorded := *cfg
+	recorded.OutputDir = projectPath
+
 	projects := GetRecentProjects()
 
 	// Remove if already exists
 	for i, p := range projects {
-		if p == projectPath {
+		if p.Path == projectPath {
 			projects = append(projects[:i], projects[i+1:]...)
 			break
 		}
 	}
 
 	// Add to front
-	projects = append([]string{projectPath}, projects...)
+	projects = append([]RecentProject{{
+		Path:        projectPath,
+		Config:      recorded,
+		GeneratedAt: time.Now(),
+	}}, projects...)
 
 	// Limit to 10 recent projects
 	if len(projects) > 10 {
 		projects = projects[:10]
 	}
 
-	viper.Set("recent_projects", projects)
-	return SaveConfig()
+	// Store plain maps so viper writes them with the JSON field names
+	var stored []interface{}
+	data, err := json.Marshal(projects)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+
+	store, err := recentProjectsStore()
+	if err != nil {
+		return err
+	}
+	store.Set("recent_projects", stored)
+	return store.WriteConfig()
+}
+
+// CloneProjectConfig copies a project configuration under a new project name.
+// The last element of the module path and output directory follow the new name.
+func CloneProjectConfig(cfg *ProjectConfig, name string) *ProjectConfig {
+	clone := *cfg
+	clone.ProjectName = name
+	clone.DevOps.Tools = append([]string{}, cfg.DevOps.Tools...)
+
+	if i := strings.LastIndex(cfg.ModulePath, "/"); i >= 0 {
+		clone.ModulePath = cfg.ModulePath[:i+1] + name
+	} else {
+		clone.ModulePath = GetConfig().DefaultModulePrefix + "/" + name
+	}
+
+	if cfg.OutputDir != "" {
+		clone.OutputDir = filepath.Join(filepath.Dir(cfg.OutputDir), name)
+	} else {
+		clone.OutputDir = "./" + name
+	}
+
+	clone.Description = fmt.Sprintf("%s backend API", name)
+	now := time.Now()
+	clone.CreatedAt = now
+	clone.UpdatedAt = now
+	return &clone
 }
 
 // ResetConfig resets configuration to defaults
@@ -0,0 +1,79 @@
+// pkg/config/config_test.go
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// TestAddRecentProject checks that the recent projects are kept in their own
+// file and that the application config file is left alone
+func TestAddRecentProject(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	appConfig := filepath.Join(home, ".goback.yaml")
+	if err := os.WriteFile(appConfig, []byte("theme: dark\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.SetConfigFile(appConfig)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+	// A flag value bound to the configuration, as the new command does
+	viper.Set("framework", "gin")
+
+	for _, name := range []string{"orders", "billing", "orders"} {
+		cfg := &ProjectConfig{ProjectName: name, OutputDir: filepath.Join(home, name), Framework: FrameworkChi}
+		if err := AddRecentProject(cfg); err != nil {
+			t.Fatalf("AddRecentProject(%s) error = %v", name, err)
+		}
+	}
+
+	projects := GetRecentProjects()
+	if len(projects) != 2 {
+		t.Fatalf("GetRecentProjects() returned %d projects, want 2", len(projects))
+	}
+	if projects[0].Config.ProjectName != "orders" || projects[1].Config.ProjectName != "billing" {
+		t.Errorf("GetRecentProjects() = %s, %s, want orders, billing", projects[0].Config.ProjectName, projects[1].Config.ProjectName)
+	}
+	if projects[0].Config.Framework != FrameworkChi {
+		t.Errorf("recorded framework = %s, want chi", projects[0].Config.Framework)
+	}
+
+	data, err := os.ReadFile(appConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "theme: dark\n" {
+		t.Errorf("the application config file was rewritten:\n%s", data)
+	}
+	data, err = os.ReadFile(filepath.Join(home, ".config", "goback", recentProjectsFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "theme") || !strings.Contains(string(data), "recent_projects") {
+		t.Errorf("the recent projects file holds more than the recent projects:\n%s", data)
+	}
+}
+
+// TestGetRecentProjectsFromAppConfig checks that a list written by older
+// versions to the application config file is still read
+func TestGetRecentProjectsFromAppConfig(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.Set("recent_projects", []interface{}{"/tmp/orders"})
+
+	projects := GetRecentProjects()
+	if len(projects) != 1 || projects[0].Path != "/tmp/orders" {
+		t.Errorf("GetRecentProjects() = %+v, want /tmp/orders", projects)
+	}
+}
@@ -0,0 +1,76 @@
+// pkg/config/goversion.go
+
+package config
+
+import (
+	"fmt"
+	"go/version"
+	"os/exec"
+	"strings"
+)
+
+const (
+	// MinGoVersion is the oldest Go release the templates and their dependencies support
+	MinGoVersion = "1.21"
+	// DefaultGoVersion is used when no Go toolchain can be detected
+	DefaultGoVersion = "1.22.0"
+)
+
+// DetectGoVersion returns the version of the local Go toolchain as reported by
+// 'go env GOVERSION', or DefaultGoVersion when it cannot be determined.
+func DetectGoVersion() string {
+	out, err := exec.Command("go", "env", "GOVERSION").Output()
+	if err != nil {
+		return DefaultGoVersion
+	}
+	// Release builds report e.g. go1.22.3; experiments append " X:..." to it
+	fields := strings.Fields(string(out))
+	if len(fields) == 0 {
+		return DefaultGoVersion
+	}
+	detected, err := NormalizeGoVersion(fields[0])
+	if err != nil {
+		return DefaultGoVersion
+	}
+	return detected
+}
+
+// NormalizeGoVersion validates a Go release version such as 1.22, 1.22.3 or
+// go1.22.3 and returns it without the go prefix. Pre-releases are rejected.
+func NormalizeGoVersion(v string) (string, error) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "go")
+	if !version.IsValid("go"+v) || strings.Trim(v, "0123456789.") != "" || !strings.Contains(v, ".") {
+		return "", fmt.Errorf("invalid Go version %q, expected a release like 1.22 or 1.22.3", v)
+	}
+	return v, nil
+}
+
+// CompareGoVersions compares two Go versions like 1.21 and 1.22.3, returning
+// -1, 0 or +1 like strings.Compare.
+func CompareGoVersions(a, b string) int {
+	return version.Compare("go"+strings.TrimPrefix(a, "go"), "go"+strings.TrimPrefix(b, "go"))
+}
+
+// goVersion returns the configured Go version, or DefaultGoVersion when it is unset
+func (c *ProjectConfig) goVersion() string {
+	if v, err := NormalizeGoVersion(c.GoVersion); err == nil {
+		return v
+	}
+	return DefaultGoVersion
+}
+
+// GoLanguageVersion returns the language version for the go directive, e.g. 1.22
+func (c *ProjectConfig) GoLanguageVersion() string {
+	return strings.TrimPrefix(version.Lang("go"+c.goVersion()), "go")
+}
+
+// GoToolchainVersion returns the full release used for the toolchain directive
+// and the builder images, e.g. 1.22.3. A version without a patch release
+// refers to its first release, 1.22.0.
+func (c *ProjectConfig) GoToolchainVersion() string {
+	v := c.goVersion()
+	if strings.Count(v, ".") == 1 {
+		v += ".0"
+	}
+	return v
+}
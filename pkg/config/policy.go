@@ -0,0 +1,308 @@
+// pkg/config/policy.go
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Policy categories, named like the keys of the allow and deny lists
+const (
+	PolicyKinds         = "kinds"
+	PolicyFrameworks    = "frameworks"
+	PolicyDatabases     = "databases"
+	PolicyTools         = "tools"
+	PolicyArchitectures = "architectures"
+	PolicyLicenses      = "licenses"
+	PolicyDevOpsTools   = "devops_tools"
+)
+
+// Policy restricts the projects that can be generated, e.g. to the stacks an
+// organization supports. It is read from a YAML (or JSON) file:
+//
+//	name: acme
+//	deny:
+//	  databases: [sqlite]
+//	require:
+//	  module_prefix: git.acme.io/
+//	  devops_tools: [helm]
+type Policy struct {
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// Allow lists the only choices that may be used; an empty list allows all
+	Allow PolicyChoices `json:"allow,omitempty" yaml:"allow,omitempty"`
+	// Deny lists choices that may not be used
+	Deny PolicyChoices `json:"deny,omitempty" yaml:"deny,omitempty"`
+	// Require holds values every project must have
+	Require PolicyRequirements `json:"require,omitempty" yaml:"require,omitempty"`
+
+	// Path is the file the policy was loaded from
+	Path string `json:"-" yaml:"-"`
+}
+
+// PolicyChoices lists choice IDs by category
+type PolicyChoices struct {
+	Kinds         []string `json:"kinds,omitempty" yaml:"kinds,omitempty"`
+	Frameworks    []string `json:"frameworks,omitempty" yaml:"frameworks,omitempty"`
+	Databases     []string `json:"databases,omitempty" yaml:"databases,omitempty"`
+	Tools         []string `json:"tools,omitempty" yaml:"tools,omitempty"`
+	Architectures []string `json:"architectures,omitempty" yaml:"architectures,omitempty"`
+	Licenses      []string `json:"licenses,omitempty" yaml:"licenses,omitempty"`
+	DevOpsTools   []string `json:"devops_tools,omitempty" yaml:"devops_tools,omitempty"`
+}
+
+// PolicyRequirements are values every generated project must have
+type PolicyRequirements struct {
+	ModulePrefix string   `json:"module_prefix,omitempty" yaml:"module_prefix,omitempty"`
+	DevOpsTools  []string `json:"devops_tools,omitempty" yaml:"devops_tools,omitempty"`
+	License      string   `json:"license,omitempty" yaml:"license,omitempty"`
+}
+
+// PolicyViolation is a policy rule that a project configuration breaks
+type PolicyViolation struct {
+	// Rule names the broken rule, e.g. deny.databases or require.module_prefix
+	Rule string
+	// Field is the JSON name of the offending project configuration field
+	Field   string
+	Message string
+}
+
+func (v PolicyViolation) Error() string {
+	return fmt.Sprintf("Policy rule %s: %s", v.Rule, v.Message)
+}
+
+var activePolicy *Policy
+
+// SetPolicy sets the policy enforced by ValidateNewProject; nil removes it
+func SetPolicy(policy *Policy) {
+	activePolicy = policy
+}
+
+// ActivePolicy returns the enforced policy, or nil when there is none
+func ActivePolicy() *Policy {
+	return activePolicy
+}
+
+// LoadPolicy reads a policy file and checks that it only names known choices
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy %s: %w", path, err)
+	}
+
+	policy := &Policy{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(policy); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse policy %s: %w", path, err)
+	}
+	policy.Path = path
+
+	for _, rule := range []struct {
+		name    string
+		choices *PolicyChoices
+	}{{"allow", &policy.Allow}, {"deny", &policy.Deny}} {
+		for _, category := range policyCategories {
+			list := rule.choices.list(category)
+			for i, value := range list {
+				list[i] = strings.ToLower(strings.TrimSpace(value))
+				if !isValidPolicyChoice(category, list[i]) {
+					return nil, fmt.Errorf("policy %s: %s.%s names unknown choice %q", path, rule.name, category, value)
+				}
+			}
+		}
+	}
+	for i, tool := range policy.Require.DevOpsTools {
+		policy.Require.DevOpsTools[i] = strings.ToLower(strings.TrimSpace(tool))
+		if !IsValidDevOpsTool(policy.Require.DevOpsTools[i]) {
+			return nil, fmt.Errorf("policy %s: require.devops_tools names unknown tool %q", path, tool)
+		}
+	}
+	if policy.Require.License != "" {
+		policy.Require.License = strings.ToLower(policy.Require.License)
+		if !IsValidLicense(LicenseChoice(policy.Require.License)) {
+			return nil, fmt.Errorf("policy %s: require.license names unknown license %q", path, policy.Require.License)
+		}
+	}
+	return policy, nil
+}
+
+var policyCategories = []string{
+	PolicyKinds, PolicyFrameworks, PolicyDatabases, PolicyTools, PolicyArchitectures, PolicyLicenses, PolicyDevOpsTools,
+}
+
+// list returns the choices of a category
+func (c *PolicyChoices) list(category string) []string {
+	switch category {
+	case PolicyKinds:
+		return c.Kinds
+	case PolicyFrameworks:
+		return c.Frameworks
+	case PolicyDatabases:
+		return c.Databases
+	case PolicyTools:
+		return c.Tools
+	case PolicyArchitectures:
+		return c.Architectures
+	case PolicyLicenses:
+		return c.Licenses
+	case PolicyDevOpsTools:
+		return c.DevOpsTools
+	}
+	return nil
+}
+
+// isValidPolicyChoice checks a choice ID of a policy category
+func isValidPolicyChoice(category, value string) bool {
+	switch category {
+	case PolicyKinds:
+		return IsValidProjectKind(ProjectKind(value))
+	case PolicyFrameworks:
+		return IsValidFramework(FrameworkChoice(value))
+	case PolicyDatabases:
+		return IsValidDatabase(DatabaseChoice(value))
+	case PolicyTools:
+		return IsValidTool(ToolChoice(value))
+	case PolicyArchitectures:
+		return IsValidArchitecture(ArchitectureChoice(value))
+	case PolicyLicenses:
+		return IsValidLicense(LicenseChoice(value))
+	case PolicyDevOpsTools:
+		return IsValidDevOpsTool(value)
+	}
+	return false
+}
+
+// Allows reports whether the policy permits a choice. A nil policy allows everything.
+func (p *Policy) Allows(category, value string) bool {
+	return p.ruleAgainst(category, value) == ""
+}
+
+// ruleAgainst returns the rule that forbids a choice, or "" when it is allowed
+func (p *Policy) ruleAgainst(category, value string) string {
+	if p == nil || value == "" {
+		return ""
+	}
+	value = strings.ToLower(value)
+	if slices.Contains(p.Deny.list(category), value) {
+		return "deny." + category
+	}
+	if allow := p.Allow.list(category); len(allow) > 0 && !slices.Contains(allow, value) {
+		return "allow." + category
+	}
+	return ""
+}
+
+// RequiresDevOps reports whether the policy requires DevOps tools
+func (p *Policy) RequiresDevOps() bool {
+	return p != nil && len(p.Require.DevOpsTools) > 0
+}
+
+// RequiredDevOpsTools returns the DevOps tools every project must include
+func (p *Policy) RequiredDevOpsTools() []string {
+	if p == nil {
+		return nil
+	}
+	return p.Require.DevOpsTools
+}
+
+// RequiredLicense returns the license every project must have, or ""
+func (p *Policy) RequiredLicense() LicenseChoice {
+	if p == nil {
+		return ""
+	}
+	return LicenseChoice(p.Require.License)
+}
+
+// ModulePrefix returns the module path prefix the policy requires, or ""
+func (p *Policy) ModulePrefix() string {
+	if p == nil {
+		return ""
+	}
+	return p.Require.ModulePrefix
+}
+
+// Check returns the policy rules that cfg breaks
+func (p *Policy) Check(cfg *ProjectConfig) []PolicyViolation {
+	if p == nil {
+		return nil
+	}
+
+	var violations []PolicyViolation
+	check := func(category, field, label, value string) {
+		rule := p.ruleAgainst(category, value)
+		switch {
+		case rule == "":
+		case strings.HasPrefix(rule, "deny."):
+			violations = append(violations, PolicyViolation{rule, field, fmt.Sprintf("%s %s is not allowed.", label, value)})
+		default:
+			violations = append(violations, PolicyViolation{rule, field, fmt.Sprintf("%s %s is not allowed, use one of: %s.",
+				label, value, strings.Join(p.Allow.list(category), ", "))})
+		}
+	}
+
+	kind := cfg.Kind
+	if kind == "" {
+		kind = KindAPI
+	}
+	check(PolicyKinds, "kind", "kind", string(kind))
+	if kind.HasAPI() {
+		check(PolicyFrameworks, "framework", "framework", string(cfg.Framework))
+	}
+	check(PolicyDatabases, "database", "database", string(cfg.Database))
+	check(PolicyTools, "tool", "tool", string(cfg.Tool))
+	check(PolicyArchitectures, "architecture", "architecture", string(cfg.Architecture))
+	check(PolicyLicenses, "license", "license", string(cfg.License))
+	if cfg.DevOps.Enabled {
+		for _, tool := range cfg.DevOps.Tools {
+			check(PolicyDevOpsTools, "devops", "DevOps tool", tool)
+		}
+	}
+
+	if prefix := p.Require.ModulePrefix; prefix != "" && !strings.HasPrefix(cfg.ModulePath, prefix) {
+		violations = append(violations, PolicyViolation{"require.module_prefix", "module_path",
+			fmt.Sprintf("the module path must start with %s.", prefix)})
+	}
+	for _, tool := range p.Require.DevOpsTools {
+		if !cfg.DevOps.Enabled || !slices.Contains(cfg.DevOps.Tools, tool) {
+			violations = append(violations, PolicyViolation{"require.devops_tools", "devops",
+				fmt.Sprintf("DevOps tool %s is required.", tool)})
+		}
+	}
+	if license := p.Require.License; license != "" && string(cfg.License) != license {
+		violations = append(violations, PolicyViolation{"require.license", "license",
+			fmt.Sprintf("license %s is required.", license)})
+	}
+	return violations
+}
+
+// AllowedChoices filters choices down to the ones the policy allows. When the
+// policy allows none of them all are returned, so that validation names the rule.
+func AllowedChoices[T ~string](p *Policy, category string, choices []T) []T {
+	var allowed []T
+	for _, choice := range choices {
+		if p.Allows(category, string(choice)) {
+			allowed = append(allowed, choice)
+		}
+	}
+	if len(allowed) == 0 {
+		return choices
+	}
+	return allowed
+}
+
+// DefaultModulePath returns the module path suggested for a new project, under
+// the module prefix the active policy requires or under github.com/user
+func DefaultModulePath(name string) string {
+	if prefix := ActivePolicy().ModulePrefix(); prefix != "" {
+		return strings.TrimSuffix(prefix, "/") + "/" + name
+	}
+	return "github.com/user/" + name
+}
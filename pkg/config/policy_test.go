@@ -0,0 +1,129 @@
+// pkg/config/policy_test.go
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestPolicyCheck(t *testing.T) {
+	policy := &Policy{
+		Allow: PolicyChoices{Frameworks: []string{"chi", "gin"}},
+		Deny:  PolicyChoices{Databases: []string{"sqlite"}, DevOpsTools: []string{"ansible"}},
+		Require: PolicyRequirements{
+			ModulePrefix: "git.acme.io/",
+			DevOpsTools:  []string{"helm"},
+			License:      "apache-2.0",
+		},
+	}
+	valid := func() *ProjectConfig {
+		return &ProjectConfig{
+			ModulePath:   "git.acme.io/billing",
+			Kind:         KindAPI,
+			Framework:    FrameworkChi,
+			Database:     DatabasepostgresQL,
+			Tool:         ToolGorm,
+			Architecture: ArchitectureClean,
+			License:      LicenseApache2,
+			DevOps:       DevOpsConfig{Enabled: true, Tools: []string{"helm"}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		change func(cfg *ProjectConfig)
+		want   []string
+	}{
+		{"valid", func(cfg *ProjectConfig) {}, nil},
+		{"framework not allowed", func(cfg *ProjectConfig) { cfg.Framework = FrameworkFiber }, []string{"allow.frameworks"}},
+		{"framework of a worker", func(cfg *ProjectConfig) {
+			cfg.Kind = KindWorker
+			cfg.Framework = FrameworkFiber
+		}, nil},
+		{"kind defaults to api", func(cfg *ProjectConfig) {
+			cfg.Kind = ""
+			cfg.Framework = FrameworkEcho
+		}, []string{"allow.frameworks"}},
+		{"denied database", func(cfg *ProjectConfig) { cfg.Database = DatabaseSQLite }, []string{"deny.databases"}},
+		{"denied devops tool", func(cfg *ProjectConfig) {
+			cfg.DevOps.Tools = []string{"helm", "ansible"}
+		}, []string{"deny.devops_tools"}},
+		{"module prefix", func(cfg *ProjectConfig) { cfg.ModulePath = "github.com/acme/billing" }, []string{"require.module_prefix"}},
+		{"required devops tool missing", func(cfg *ProjectConfig) {
+			cfg.DevOps = DevOpsConfig{Enabled: true, Tools: []string{"terraform"}}
+		}, []string{"require.devops_tools"}},
+		{"devops disabled", func(cfg *ProjectConfig) { cfg.DevOps.Enabled = false }, []string{"require.devops_tools"}},
+		{"required license", func(cfg *ProjectConfig) { cfg.License = LicenseMIT }, []string{"require.license"}},
+		{"several rules", func(cfg *ProjectConfig) {
+			cfg.Framework = FrameworkFiber
+			cfg.Database = DatabaseSQLite
+			cfg.License = ""
+		}, []string{"allow.frameworks", "deny.databases", "require.license"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.change(cfg)
+			var rules []string
+			for _, violation := range policy.Check(cfg) {
+				rules = append(rules, violation.Rule)
+			}
+			if !slices.Equal(rules, tt.want) {
+				t.Errorf("Check() broke %v, want %v", rules, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyCheckNil(t *testing.T) {
+	var policy *Policy
+	if violations := policy.Check(&ProjectConfig{Database: DatabaseSQLite}); violations != nil {
+		t.Errorf("a nil policy broke %v", violations)
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{"valid", "deny:\n  databases: [SQLite]\nrequire:\n  devops_tools: [Helm]\n", false},
+		{"empty", "", false},
+		{"unknown choice", "allow:\n  frameworks: [django]\n", true},
+		{"unknown field", "deny:\n  orms: [gorm]\n", true},
+		{"unknown required tool", "require:\n  devops_tools: [pulumi]\n", true},
+		{"unknown required license", "require:\n  license: gpl\n", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "policy.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadPolicy(path); (err != nil) != tt.wantErr {
+				t.Errorf("LoadPolicy() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadPolicyLowercasesChoices(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(path, []byte("deny:\n  databases: [SQLite]\nrequire:\n  devops_tools: [Helm]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	policy, err := LoadPolicy(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if policy.Allows(PolicyDatabases, "sqlite") {
+		t.Error("the denied database sqlite is allowed")
+	}
+	if !slices.Equal(policy.RequiredDevOpsTools(), []string{"helm"}) {
+		t.Errorf("RequiredDevOpsTools() = %v, want [helm]", policy.RequiredDevOpsTools())
+	}
+}
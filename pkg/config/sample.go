@@ -0,0 +1,47 @@
+// pkg/config/sample.go
+
+package config
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Sample domain choices; any other value names a custom sample entity
+const (
+	SampleNone = "none"
+	SampleUser = "user"
+)
+
+// DefaultSampleFields are the fields of a custom sample entity when none are given
+const DefaultSampleFields = "name:string"
+
+var sampleEntityName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// IsValidSample checks a sample choice: none, user or an entity name
+func IsValidSample(sample string) bool {
+	return sample == "" || sampleEntityName.MatchString(sample)
+}
+
+// HasUserSample reports whether the project gets the built-in User sample,
+// which is the default
+func (c *ProjectConfig) HasUserSample() bool {
+	return c.Sample == "" || strings.EqualFold(c.Sample, SampleUser)
+}
+
+// SampleEntity returns the name of the custom sample entity, or "" when the
+// project has no sample or the built-in User sample
+func (c *ProjectConfig) SampleEntity() string {
+	if c.HasUserSample() || strings.EqualFold(c.Sample, SampleNone) {
+		return ""
+	}
+	return c.Sample
+}
+
+// SampleFieldSpec returns the fields of the custom sample entity, defaulting to DefaultSampleFields
+func (c *ProjectConfig) SampleFieldSpec() string {
+	if c.SampleFields == "" {
+		return DefaultSampleFields
+	}
+	return c.SampleFields
+}
@@ -0,0 +1,210 @@
+// pkg/config/schema.go
+
+package config
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+const schemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema kinds accepted by GetSchema
+const (
+	SchemaProject = "project"
+	SchemaApp     = "app"
+)
+
+// schemaDescriptions documents the configuration keys, indexed by their JSON name
+var schemaDescriptions = map[string]string{
+	"project_name":          "Name of the project, used for the binary, containers and charts",
+	"module_path":           "Go module path, e.g. github.com/user/project",
+	"description":           "Short description of the project",
+	"output_dir":            "Directory the project is generated into",
+	"repo_mode":             "How a project inside an existing Go module or git repository relates to it: standalone, workspace (nested module in go.work) or package (no go.mod)",
+	"kind":                  "Kind of project: api (default), worker, cli or api+worker",
+	"framework":             "Web framework used by the project, required for the api kinds",
+	"database":              "Database used by the project",
+	"tool":                  "Database access tool",
+	"architecture":          "Architectural pattern of the project layout",
+	"devops":                "DevOps tool configuration",
+	"enabled":               "Generate DevOps configuration files",
+	"tools":                 "DevOps tools to include",
+	"kubernetes":            "Include Kubernetes manifests",
+	"helm":                  "Include a Helm chart",
+	"terraform":             "Include Terraform configuration",
+	"ansible":               "Include an Ansible playbook",
+	"license":               "License to generate a LICENSE file for",
+	"author":                "Copyright holder named in the LICENSE file and source headers",
+	"spdx_header":           "Add an SPDX license header to every generated Go file",
+	"platform":              "Module path of the monorepo platform whose shared packages the service uses, set by 'goback add service'",
+	"go_version":            "Go release for go.mod, the toolchain directive and the builder images, e.g. 1.22.3",
+	"sample":                "Sample domain: none, user (default) or the name of a custom entity",
+	"sample_fields":         "Fields of a custom sample entity, e.g. name:string,price:decimal",
+	"values":                "Template values, e.g. port, available to templates as .Values and set with --set or --values",
+	"created_at":            "Time the configuration was created",
+	"updated_at":            "Time the configuration was last updated",
+	"default_output_dir":    "Default directory new projects are generated into",
+	"default_module_prefix": "Module path prefix used when no module path is given",
+	"default_author":        "Author recorded in generated projects",
+	"animation_speed":       "TUI animation speed in milliseconds",
+	"show_splash_screen":    "Show the splash screen when the TUI starts",
+	"auto_save":             "Save configuration changes automatically",
+	"theme":                 "TUI color theme",
+	"policy":                "Organization policy file enforced for new projects, relative to this file",
+	"path":                  "Absolute path of the generated project",
+	"config":                "Configuration the project was generated with",
+	"generated_at":          "Time the project was generated",
+}
+
+// GetSchemaKinds returns the configuration kinds a JSON Schema can be generated for
+func GetSchemaKinds() []string {
+	return []string{SchemaProject, SchemaApp}
+}
+
+// GetSchema returns the JSON Schema for the given configuration kind.
+// It returns nil when the kind is unknown.
+func GetSchema(kind string) map[string]interface{} {
+	var (
+		schema map[string]interface{}
+		title  string
+	)
+
+	switch kind {
+	case SchemaProject:
+		schema = schemaFor(reflect.TypeOf(ProjectConfig{}))
+		title = "GoBack project configuration"
+
+		// What 'goback new --from-file' needs from the file; the module path and
+		// the output directory default to values derived from the project name
+		schema["required"] = []string{"project_name", "database", "tool", "architecture"}
+		schema["if"] = map[string]interface{}{
+			"anyOf": []interface{}{
+				map[string]interface{}{"not": map[string]interface{}{"required": []string{"kind"}}},
+				map[string]interface{}{"properties": map[string]interface{}{"kind": map[string]interface{}{"enum": apiKinds()}}},
+			},
+		}
+		schema["then"] = map[string]interface{}{"required": []string{"framework"}}
+	case SchemaApp:
+		schema = schemaFor(reflect.TypeOf(AppConfig{}))
+		title = "GoBack application configuration"
+
+		// Files written by older versions also hold the recent projects and the
+		// flags bound to the configuration
+		schema["additionalProperties"] = true
+	default:
+		return nil
+	}
+
+	schema["$schema"] = schemaDraft
+	schema["$id"] = "https://github.com/NarmadaWeb/goback/schemas/" + kind + ".json"
+	schema["title"] = title
+	return schema
+}
+
+// apiKinds returns the project kinds that need a framework
+func apiKinds() []string {
+	var kinds []string
+	for _, kind := range GetValidProjectKinds() {
+		if kind.HasAPI() {
+			kinds = append(kinds, string(kind))
+		}
+	}
+	return kinds
+}
+
+// schemaFor builds the JSON Schema of a Go type from its json tags
+func schemaFor(t reflect.Type) map[string]interface{} {
+	if enum := choiceEnum(t); enum != nil {
+		return map[string]interface{}{"type": "string", "enum": enum}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": schemaFor(t.Elem())}
+	case reflect.Ptr:
+		return schemaFor(t.Elem())
+	case reflect.Struct:
+		if t == reflect.TypeOf(time.Time{}) {
+			return map[string]interface{}{"type": "string", "format": "date-time"}
+		}
+	default:
+		return map[string]interface{}{}
+	}
+
+	properties := map[string]interface{}{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := schemaFor(field.Type)
+		if description, ok := schemaDescriptions[name]; ok {
+			property["description"] = description
+		}
+		// The DevOps tool list shares the plain string type, so its enum is attached here
+		if t == reflect.TypeOf(DevOpsConfig{}) && name == "tools" {
+			property["items"] = map[string]interface{}{"type": "string", "enum": GetValidDevOpsTools()}
+		}
+		properties[name] = property
+	}
+
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
+
+// choiceEnum returns the valid values for the choice types, or nil for other types
+func choiceEnum(t reflect.Type) []string {
+	var values []string
+	switch t {
+	case reflect.TypeOf(ProjectKind("")):
+		for _, v := range GetValidProjectKinds() {
+			values = append(values, string(v))
+		}
+	case reflect.TypeOf(FrameworkChoice("")):
+		for _, v := range GetValidFrameworks() {
+			values = append(values, string(v))
+		}
+	case reflect.TypeOf(DatabaseChoice("")):
+		for _, v := range GetValidDatabases() {
+			values = append(values, string(v))
+		}
+	case reflect.TypeOf(ToolChoice("")):
+		for _, v := range GetValidTools() {
+			values = append(values, string(v))
+		}
+	case reflect.TypeOf(ArchitectureChoice("")):
+		for _, v := range GetValidArchitectures() {
+			values = append(values, string(v))
+		}
+	case reflect.TypeOf(LicenseChoice("")):
+		for _, v := range GetValidLicenses() {
+			values = append(values, string(v))
+		}
+	case reflect.TypeOf(RepoMode("")):
+		for _, v := range GetValidRepoModes() {
+			values = append(values, string(v))
+		}
+	}
+	return values
+}
@@ -3,41 +3,92 @@
 package config
 
 import (
+	"fmt"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 )
 
 // ProjectConfig holds all the configuration for the project to be generated
 type ProjectConfig struct {
-	ProjectName  string             `json:"project_name" validate:"required,min=1"`
-	ModulePath   string             `json:"module_path" validate:"required,modulepath"`
-	Description  string             `json:"description"`
-	OutputDir    string             `json:"output_dir" validate:"required"`
-	Framework    FrameworkChoice    `json:"framework" validate:"required"`
-	Database     DatabaseChoice     `json:"database" validate:"required"`
-	Tool         ToolChoice         `json:"tool" validate:"required"`
-	Architecture ArchitectureChoice `json:"architecture" validate:"required"`
-	DevOps       DevOpsConfig       `json:"devops"`
-	CreatedAt    time.Time          `json:"created_at"`
-	UpdatedAt    time.Time          `json:"updated_at"`
+	ProjectName  string                 `json:"project_name" yaml:"project_name" mapstructure:"project_name" validate:"required,min=1"`
+	ModulePath   string                 `json:"module_path" yaml:"module_path" mapstructure:"module_path" validate:"required,modulepath"`
+	Description  string                 `json:"description" yaml:"description" mapstructure:"description"`
+	OutputDir    string                 `json:"output_dir" yaml:"output_dir" mapstructure:"output_dir" validate:"required"`
+	RepoMode     RepoMode               `json:"repo_mode,omitempty" yaml:"repo_mode,omitempty" mapstructure:"repo_mode"`
+	Platform     string                 `json:"platform,omitempty" yaml:"platform,omitempty" mapstructure:"platform"`
+	Kind         ProjectKind            `json:"kind,omitempty" yaml:"kind,omitempty" mapstructure:"kind"`
+	Framework    FrameworkChoice        `json:"framework,omitempty" yaml:"framework,omitempty" mapstructure:"framework"`
+	Database     DatabaseChoice         `json:"database" yaml:"database" mapstructure:"database" validate:"required"`
+	Tool         ToolChoice             `json:"tool" yaml:"tool" mapstructure:"tool" validate:"required"`
+	Architecture ArchitectureChoice     `json:"architecture" yaml:"architecture" mapstructure:"architecture" validate:"required"`
+	DevOps       DevOpsConfig           `json:"devops" yaml:"devops" mapstructure:"devops"`
+	License      LicenseChoice          `json:"license,omitempty" yaml:"license,omitempty" mapstructure:"license"`
+	Author       string                 `json:"author,omitempty" yaml:"author,omitempty" mapstructure:"author"`
+	SPDXHeader   bool                   `json:"spdx_header,omitempty" yaml:"spdx_header,omitempty" mapstructure:"spdx_header"`
+	GoVersion    string                 `json:"go_version,omitempty" yaml:"go_version,omitempty" mapstructure:"go_version"`
+	Sample       string                 `json:"sample,omitempty" yaml:"sample,omitempty" mapstructure:"sample"`
+	SampleFields string                 `json:"sample_fields,omitempty" yaml:"sample_fields,omitempty" mapstructure:"sample_fields"`
+	Values       map[string]interface{} `json:"values,omitempty" yaml:"values,omitempty" mapstructure:"values"`
+	CreatedAt    time.Time              `json:"created_at" yaml:"created_at" mapstructure:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at" yaml:"updated_at" mapstructure:"updated_at"`
 }
 
 // DevOpsConfig holds the DevOps tool configuration
 type DevOpsConfig struct {
-	Enabled    bool     `json:"enabled"`
-	Tools      []string `json:"tools"`
-	Kubernetes bool     `json:"kubernetes"`
-	Helm       bool     `json:"helm"`
-	Terraform  bool     `json:"terraform"`
-	Ansible    bool     `json:"ansible"`
+	Enabled    bool     `json:"enabled" yaml:"enabled" mapstructure:"enabled"`
+	Tools      []string `json:"tools" yaml:"tools" mapstructure:"tools"`
+	Kubernetes bool     `json:"kubernetes" yaml:"kubernetes" mapstructure:"kubernetes"`
+	Helm       bool     `json:"helm" yaml:"helm" mapstructure:"helm"`
+	Terraform  bool     `json:"terraform" yaml:"terraform" mapstructure:"terraform"`
+	Ansible    bool     `json:"ansible" yaml:"ansible" mapstructure:"ansible"`
+}
+
+// WithTools returns the configuration with tools added and DevOps enabled.
+// Tool names are case insensitive; unknown tools are an error.
+func (d DevOpsConfig) WithTools(tools []string) (DevOpsConfig, error) {
+	valid := GetValidDevOpsTools()
+	configured := slices.Clone(d.Tools)
+	for _, tool := range tools {
+		name := strings.ToLower(tool)
+		if !slices.Contains(valid, name) {
+			return d, fmt.Errorf("unknown DevOps tool %q, valid tools: %s", tool, strings.Join(valid, ", "))
+		}
+		if !slices.Contains(configured, name) {
+			configured = append(configured, name)
+		}
+		switch name {
+		case DevOpsHelm:
+			d.Helm = true
+		case DevOpsTerraform:
+			d.Terraform = true
+		case DevOpsAnsible:
+			d.Ansible = true
+		}
+	}
+	sort.Strings(configured)
+	d.Tools, d.Enabled = configured, true
+	return d, nil
 }
 
 // Choice types for project configuration
 type (
+	ProjectKind        string
 	FrameworkChoice    string
 	DatabaseChoice     string
 	ToolChoice         string
 	ArchitectureChoice string
+	LicenseChoice      string
+	RepoMode           string
+)
+
+// Project kinds; an empty kind is an HTTP API
+const (
+	KindAPI       ProjectKind = "api"
+	KindWorker    ProjectKind = "worker"
+	KindCLI       ProjectKind = "cli"
+	KindAPIWorker ProjectKind = "api+worker"
 )
 
 // Framework choices
@@ -70,6 +121,22 @@ const (
 	ArchitectureHexagonal ArchitectureChoice = "hexagonal"
 )
 
+// License choices
+const (
+	LicenseMIT         LicenseChoice = "mit"
+	LicenseApache2     LicenseChoice = "apache-2.0"
+	LicenseBSD3        LicenseChoice = "bsd-3"
+	LicenseMPL2        LicenseChoice = "mpl-2.0"
+	LicenseProprietary LicenseChoice = "proprietary"
+)
+
+// Repository modes of a project generated inside an existing Go module or git repository
+const (
+	RepoStandalone RepoMode = "standalone"
+	RepoWorkspace  RepoMode = "workspace"
+	RepoPackage    RepoMode = "package"
+)
+
 // DevOps tool choices
 const (
 	DevOpsHelm      = "helm"
@@ -79,6 +146,16 @@ const (
 
 // Validation functions
 
+// IsValidProjectKind checks if project kind is valid
+func IsValidProjectKind(kind ProjectKind) bool {
+	for _, valid := range GetValidProjectKinds() {
+		if kind == valid {
+			return true
+		}
+	}
+	return false
+}
+
 // IsValidFramework checks if framework choice is valid
 func IsValidFramework(framework FrameworkChoice) bool {
 	validFrameworks := []FrameworkChoice{
@@ -145,6 +222,16 @@ func IsValidArchitecture(architecture ArchitectureChoice) bool {
 	return false
 }
 
+// IsValidLicense checks if license choice is valid
+func IsValidLicense(license LicenseChoice) bool {
+	for _, valid := range GetValidLicenses() {
+		if license == valid {
+			return true
+		}
+	}
+	return false
+}
+
 // IsValidDevOpsTool checks if DevOps tool is valid
 func IsValidDevOpsTool(tool string) bool {
 	validTools := []string{
@@ -161,6 +248,35 @@ func IsValidDevOpsTool(tool string) bool {
 	return false
 }
 
+// IsValidRepoMode checks if repository mode is valid
+func IsValidRepoMode(mode RepoMode) bool {
+	for _, valid := range GetValidRepoModes() {
+		if mode == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// GetValidRepoModes returns list of valid repository modes
+func GetValidRepoModes() []RepoMode {
+	return []RepoMode{
+		RepoStandalone,
+		RepoWorkspace,
+		RepoPackage,
+	}
+}
+
+// GetValidProjectKinds returns list of valid project kinds
+func GetValidProjectKinds() []ProjectKind {
+	return []ProjectKind{
+		KindAPI,
+		KindWorker,
+		KindCLI,
+		KindAPIWorker,
+	}
+}
+
 // GetValidFrameworks returns list of valid framework choices
 func GetValidFrameworks() []FrameworkChoice {
 	return []FrameworkChoice{
@@ -199,6 +315,17 @@ func GetValidArchitectures() []ArchitectureChoice {
 	}
 }
 
+// GetValidLicenses returns list of valid license choices
+func GetValidLicenses() []LicenseChoice {
+	return []LicenseChoice{
+		LicenseMIT,
+		LicenseApache2,
+		LicenseBSD3,
+		LicenseMPL2,
+		LicenseProprietary,
+	}
+}
+
 // GetValidDevOpsTools returns list of valid DevOps tools
 func GetValidDevOpsTools() []string {
 	return []string{
@@ -210,6 +337,21 @@ func GetValidDevOpsTools() []string {
 
 // String methods for better display
 
+func (k ProjectKind) String() string {
+	switch k {
+	case KindAPI:
+		return "HTTP API"
+	case KindWorker:
+		return "Background Worker"
+	case KindCLI:
+		return "CLI Tool"
+	case KindAPIWorker:
+		return "HTTP API + Worker"
+	default:
+		return string(k)
+	}
+}
+
 func (f FrameworkChoice) String() string {
 	switch f {
 	case FrameworkFiber:
@@ -266,8 +408,53 @@ func (a ArchitectureChoice) String() string {
 	}
 }
 
+func (l LicenseChoice) String() string {
+	switch l {
+	case LicenseMIT:
+		return "MIT License"
+	case LicenseApache2:
+		return "Apache License 2.0"
+	case LicenseBSD3:
+		return "BSD 3-Clause License"
+	case LicenseMPL2:
+		return "Mozilla Public License 2.0"
+	case LicenseProprietary:
+		return "Proprietary"
+	default:
+		return string(l)
+	}
+}
+
+func (m RepoMode) String() string {
+	switch m {
+	case RepoStandalone:
+		return "Standalone module"
+	case RepoWorkspace:
+		return "Nested module in go.work"
+	case RepoPackage:
+		return "Package of the existing module"
+	default:
+		return string(m)
+	}
+}
+
 // Description methods for detailed information
 
+func (k ProjectKind) Description() string {
+	switch k {
+	case KindAPI:
+		return "HTTP API service built on a web framework"
+	case KindWorker:
+		return "Queue and cron consumer with graceful shutdown"
+	case KindCLI:
+		return "Command line tool built with cobra"
+	case KindAPIWorker:
+		return "HTTP API and worker binaries sharing the domain layers"
+	default:
+		return ""
+	}
+}
+
 func (f FrameworkChoice) Description() string {
 	switch f {
 	case FrameworkFiber:
@@ -324,6 +511,72 @@ func (a ArchitectureChoice) Description() string {
 	}
 }
 
+func (l LicenseChoice) Description() string {
+	switch l {
+	case LicenseMIT:
+		return "Short permissive license with attribution"
+	case LicenseApache2:
+		return "Permissive license with an express patent grant"
+	case LicenseBSD3:
+		return "Permissive license with a non-endorsement clause"
+	case LicenseMPL2:
+		return "Weak copyleft license applied per file"
+	case LicenseProprietary:
+		return "All rights reserved, no license granted"
+	default:
+		return ""
+	}
+}
+
+func (m RepoMode) Description() string {
+	switch m {
+	case RepoStandalone:
+		return "Own go.mod, built on its own"
+	case RepoWorkspace:
+		return "Own go.mod, added to the go.work of the repository"
+	case RepoPackage:
+		return "No go.mod, imports use the existing module path"
+	default:
+		return ""
+	}
+}
+
+// SPDXID returns the SPDX license identifier used in source file headers
+func (l LicenseChoice) SPDXID() string {
+	switch l {
+	case LicenseMIT:
+		return "MIT"
+	case LicenseApache2:
+		return "Apache-2.0"
+	case LicenseBSD3:
+		return "BSD-3-Clause"
+	case LicenseMPL2:
+		return "MPL-2.0"
+	case LicenseProprietary:
+		return "LicenseRef-Proprietary"
+	default:
+		return ""
+	}
+}
+
+// CopyrightHolder returns the name used in the LICENSE file and source headers
+func (c *ProjectConfig) CopyrightHolder() string {
+	if c.Author != "" {
+		return c.Author
+	}
+	return fmt.Sprintf("The %s Authors", c.ProjectName)
+}
+
+// CopyrightYear returns the year used in the LICENSE file and source headers:
+// the year the project was created, so that rendering it again later gives
+// the same files
+func (c *ProjectConfig) CopyrightYear() int {
+	if c.CreatedAt.IsZero() {
+		return time.Now().Year()
+	}
+	return c.CreatedAt.Year()
+}
+
 // GetDevOpsToolDescription returns description for DevOps tools
 func GetDevOpsToolDescription(tool string) string {
 	switch tool {
@@ -375,6 +628,34 @@ func GetRecommendedArchitecture(complexity string) ArchitectureChoice {
 
 // Feature flags for choices
 
+// HasAPI checks if the project serves HTTP and so needs a framework. Projects
+// generated before kinds existed have no kind and are HTTP APIs.
+func (k ProjectKind) HasAPI() bool {
+	return k == "" || k == KindAPI || k == KindAPIWorker
+}
+
+// HasWorker checks if the project has a background worker
+func (k ProjectKind) HasWorker() bool {
+	return k == KindWorker || k == KindAPIWorker
+}
+
+// HasCLI checks if the project is a command line tool
+func (k ProjectKind) HasCLI() bool {
+	return k == KindCLI
+}
+
+// MainPackage returns the directory of the project's main binary
+func (k ProjectKind) MainPackage() string {
+	switch {
+	case k.HasAPI():
+		return "cmd/api"
+	case k.HasWorker():
+		return "cmd/worker"
+	default:
+		return "cmd/cli"
+	}
+}
+
 // HasMigrations checks if the Tool supports migrations
 func (t ToolChoice) HasMigrations() bool {
 	switch t {
@@ -5,17 +5,93 @@ package config
 import (
 	"errors"
 	"fmt"
+	"os"
+	"path"
 	"path/filepath"
-	"regexp"
+	"reflect"
+	"strings"
+
+	"github.com/NarmadaWeb/goback/internal/utils"
 
 	"github.com/go-playground/validator/v10"
+	"golang.org/x/mod/modfile"
+)
+
+// Validation error codes
+const (
+	CodeRequired      = "required"
+	CodeInvalidChoice = "invalid_choice"
+	CodeInvalidFormat = "invalid_format"
+	CodeConflict      = "conflict"
+	CodePermission    = "permission"
+	CodeUnsupported   = "unsupported"
+	CodePolicy        = "policy"
 )
 
+// ValidationError is a problem with one field of a project configuration
+type ValidationError struct {
+	// Field is the JSON name of the field, e.g. framework or module_path
+	Field string `json:"field"`
+	// Code classifies the problem, e.g. required or invalid_choice
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Suggestion is the closest valid value for a mistyped choice
+	Suggestion string `json:"suggestion,omitempty"`
+	// Rule names the broken rule of a policy error
+	Rule string `json:"rule,omitempty"`
+}
+
+func (e ValidationError) Error() string {
+	if e.Suggestion != "" {
+		return fmt.Sprintf("%s Did you mean %q?", e.Message, e.Suggestion)
+	}
+	return e.Message
+}
+
+// ValidationErrors are the problems found in a project configuration
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// ForField returns the errors of one field
+func (e ValidationErrors) ForField(field string) ValidationErrors {
+	var errs ValidationErrors
+	for _, err := range e {
+		if err.Field == field {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// HasCode reports whether any error has the given code
+func (e ValidationErrors) HasCode(code string) bool {
+	for _, err := range e {
+		if err.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
 // ValidateProjectConfig validates the project configuration using struct tags
-func ValidateProjectConfig(config *ProjectConfig) []string {
-	var validationErrors []string
+func ValidateProjectConfig(config *ProjectConfig) ValidationErrors {
+	var validationErrors ValidationErrors
+	add := func(field, code, message string) {
+		validationErrors = append(validationErrors, ValidationError{Field: field, Code: code, Message: message})
+	}
 	validate := validator.New()
 
+	// Report fields by their JSON name
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	})
 	// Register custom validation for module path
 	_ = validate.RegisterValidation("modulepath", validateModulePath)
 
@@ -31,78 +107,296 @@ func ValidateProjectConfig(config *ProjectConfig) []string {
 	}
 
 	// Additional custom validations
-	if !IsValidFramework(config.Framework) {
-		validationErrors = append(validationErrors, "Invalid framework choice.")
+	if config.ProjectName != "" {
+		if err := utils.ValidateProjectName(config.ProjectName); err != nil {
+			add("project_name", CodeInvalidFormat, sentence(err))
+		}
 	}
-	if !IsValidDatabase(config.Database) {
-		validationErrors = append(validationErrors, "Invalid database choice.")
+	if config.Kind != "" && !IsValidProjectKind(config.Kind) {
+		validationErrors = append(validationErrors, invalidChoice("kind", "project kind", config.Kind, GetValidProjectKinds()))
 	}
-	if !IsValidTool(config.Tool) {
-		validationErrors = append(validationErrors, "Invalid Tool choice.")
+	switch {
+	case !IsValidProjectKind(config.Kind) && config.Kind != "":
+		// The framework cannot be checked against an unknown kind
+	case !config.Kind.HasAPI() && config.Framework != "":
+		add("framework", CodeConflict, fmt.Sprintf("The %s kind has no HTTP API, leave the framework empty.", config.Kind))
+	case config.Kind.HasAPI() && config.Framework == "":
+		add("framework", CodeRequired, "A framework selection is required.")
+	case config.Kind.HasAPI() && !IsValidFramework(config.Framework):
+		validationErrors = append(validationErrors, invalidChoice("framework", "framework", config.Framework, GetValidFrameworks()))
 	}
-	if !IsValidArchitecture(config.Architecture) {
-		validationErrors = append(validationErrors, "Invalid architecture choice.")
+	if config.Database != "" && !IsValidDatabase(config.Database) {
+		validationErrors = append(validationErrors, invalidChoice("database", "database", config.Database, GetValidDatabases()))
+	}
+	if config.Tool != "" && !IsValidTool(config.Tool) {
+		validationErrors = append(validationErrors, invalidChoice("tool", "tool", config.Tool, GetValidTools()))
+	}
+	if config.Architecture != "" && !IsValidArchitecture(config.Architecture) {
+		validationErrors = append(validationErrors, invalidChoice("architecture", "architecture", config.Architecture, GetValidArchitectures()))
+	}
+
+	if config.License != "" && !IsValidLicense(config.License) {
+		validationErrors = append(validationErrors, invalidChoice("license", "license", config.License, GetValidLicenses()))
+	}
+	if config.SPDXHeader && config.License == "" {
+		add("spdx_header", CodeConflict, "A license must be selected to add SPDX headers.")
+	}
+
+	if config.GoVersion != "" {
+		if _, err := NormalizeGoVersion(config.GoVersion); err != nil {
+			add("go_version", CodeInvalidFormat, "Invalid Go version, expected a release like 1.22 or 1.22.3.")
+		} else if CompareGoVersions(config.GoVersion, MinGoVersion) < 0 {
+			add("go_version", CodeUnsupported, fmt.Sprintf("Go %s is too old, the generated projects need Go %s or newer.", config.GoVersion, MinGoVersion))
+		}
+	}
+
+	if !IsValidSample(config.Sample) {
+		add("sample", CodeInvalidFormat, "Invalid sample, expected none, user or an entity name like Product.")
+	} else if config.SampleEntity() != "" && !config.Kind.HasAPI() {
+		add("sample", CodeConflict, "A custom sample entity needs an HTTP API, use the api or api+worker kind.")
+	}
+
+	if config.RepoMode != "" && !IsValidRepoMode(config.RepoMode) {
+		validationErrors = append(validationErrors, invalidChoice("repo_mode", "repository mode", config.RepoMode, GetValidRepoModes()))
 	}
 
 	if config.DevOps.Enabled && len(config.DevOps.Tools) == 0 {
-		validationErrors = append(validationErrors, "At least one DevOps tool must be selected when DevOps is enabled.")
+		add("devops", CodeRequired, "At least one DevOps tool must be selected when DevOps is enabled.")
 	}
 
-	if !isValidPath(config.OutputDir) {
-		validationErrors = append(validationErrors, "Invalid output directory path.")
+	return validationErrors
+}
+
+// ValidateNewProject validates the configuration of a project about to be
+// created: the configuration itself, its output directory, which must be
+// writable and fit the repository mode, and the organization policy.
+// Existing projects are validated with ValidateProjectConfig, which neither
+// looks at the file system nor enforces the policy.
+func ValidateNewProject(config *ProjectConfig) ValidationErrors {
+	validationErrors := ValidateProjectConfig(config)
+	if config.OutputDir != "" {
+		validationErrors = append(validationErrors, ValidateOutputDir(config)...)
 	}
+	return append(validationErrors, PolicyErrors(ActivePolicy().Check(config))...)
+}
 
+// PolicyErrors reports policy violations as validation errors
+func PolicyErrors(violations []PolicyViolation) ValidationErrors {
+	var validationErrors ValidationErrors
+	for _, violation := range violations {
+		validationErrors = append(validationErrors, ValidationError{
+			Field:   violation.Field,
+			Code:    CodePolicy,
+			Message: violation.Error(),
+			Rule:    violation.Rule,
+		})
+	}
 	return validationErrors
 }
 
-// formatValidationError creates a user-friendly error message
-func formatValidationError(err validator.FieldError) string {
+// formatValidationError creates a user-friendly error from a struct tag failure
+func formatValidationError(err validator.FieldError) ValidationError {
 	field := err.Field()
 	tag := err.Tag()
+	code := CodeRequired
+	if tag != "required" && tag != "min" {
+		code = CodeInvalidFormat
+	}
+	e := ValidationError{Field: field, Code: code}
 
 	switch field {
-	case "ProjectName":
-		return "Project name is required and cannot be empty."
-	case "ModulePath":
-		if tag == "required" {
-			return "Go module path is required."
-		}
+	case "project_name":
+		e.Message = "Project name is required and cannot be empty."
+	case "module_path":
 		if tag == "modulepath" {
-			return "Invalid Go module path format. (e.g., github.com/user/project)"
+			e.Message = sentence(utils.ValidateModulePath(fmt.Sprint(err.Value())))
+		} else {
+			e.Message = "Go module path is required."
 		}
-	case "OutputDir":
-		return "Output directory is required."
-	case "Framework":
-		return "A framework selection is required."
-	case "Database":
-		return "A database selection is required."
-	case "Tool":
-		return "A Tool selection is required."
-	case "Architecture":
-		return "An architecture selection is required."
+	case "output_dir":
+		e.Message = "Output directory is required."
+	case "database":
+		e.Message = "A database selection is required."
+	case "tool":
+		e.Message = "A Tool selection is required."
+	case "architecture":
+		e.Message = "An architecture selection is required."
+	default:
+		e.Message = fmt.Sprintf("Validation failed on field '%s' with tag '%s'", field, tag)
 	}
-	return fmt.Sprintf("Validation failed on field '%s' with tag '%s'", field, tag)
+	return e
+}
+
+// invalidChoice reports a value that is none of the valid choices, suggesting the closest one
+func invalidChoice[T ~string](field, label string, value T, valid []T) ValidationError {
+	choices := make([]string, len(valid))
+	for i, choice := range valid {
+		choices[i] = string(choice)
+	}
+	expected := strings.Join(choices[:len(choices)-1], ", ") + " or " + choices[len(choices)-1]
+	return ValidationError{
+		Field:      field,
+		Code:       CodeInvalidChoice,
+		Message:    fmt.Sprintf("Invalid %s %q, expected %s.", label, string(value), expected),
+		Suggestion: SuggestChoice(string(value), choices),
+	}
+}
+
+// SuggestChoice returns the choice closest to a mistyped value by edit distance,
+// or "" when none is close enough to be what was meant
+func SuggestChoice(value string, choices []string) string {
+	value = strings.ToLower(value)
+	best, bestDistance := "", -1
+	for _, choice := range choices {
+		distance := editDistance(value, strings.ToLower(choice))
+		if bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = choice, distance
+		}
+	}
+	// Allow about one typo per three characters
+	if bestDistance < 0 || bestDistance > max(1, len([]rune(best))/3) {
+		return ""
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
 }
 
 // validateModulePath is a custom validator for Go module paths
 func validateModulePath(fl validator.FieldLevel) bool {
-	// A simple regex to validate module path format.
-	// This is not exhaustive but covers common cases like github.com/user/repo
-	re := regexp.MustCompile(`^([\w-]+\.)+[\w-]+(/[\w-]+)+$`)
-	return re.MatchString(fl.Field().String())
+	return IsValidModulePath(fl.Field().String())
 }
 
-// isValidPath checks if a path is valid
-func isValidPath(path string) bool {
-	if path == "" {
-		return false
+// IsValidModulePath checks a Go module path with the rules of 'go mod init'
+func IsValidModulePath(modulePath string) bool {
+	return utils.ValidateModulePath(modulePath) == nil
+}
+
+// ValidateOutputDir reports an output directory that cannot be written, or that
+// does not fit the repository mode
+func ValidateOutputDir(config *ProjectConfig) ValidationErrors {
+	var validationErrors ValidationErrors
+	add := func(code, message string) {
+		validationErrors = append(validationErrors, ValidationError{Field: "output_dir", Code: code, Message: message})
 	}
 
-	// Basic path validation - can be enhanced
-	if filepath.IsAbs(path) {
-		return true
+	dir, err := filepath.Abs(config.OutputDir)
+	if err != nil {
+		add(CodeInvalidFormat, "Invalid output directory path.")
+		return validationErrors
+	}
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		add(CodeConflict, fmt.Sprintf("Output directory %s is a file.", config.OutputDir))
+		return validationErrors
 	}
 
-	// Relative paths are also valid
-	return !filepath.IsAbs(path)
+	// The directory is created below its nearest existing ancestor, which must be writable
+	existing := dir
+	for {
+		if _, err := os.Stat(existing); err == nil {
+			break
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			break
+		}
+		existing = parent
+	}
+	if !isWritableDir(existing) {
+		add(CodePermission, fmt.Sprintf("Output directory %s is not writable.", config.OutputDir))
+	}
+
+	// A project inside another module must say how it relates to it. A
+	// directory with a go.mod is an existing project and is its own module.
+	moduleRoot := findUp(filepath.Dir(dir), "go.mod")
+	switch config.RepoMode {
+	case "":
+		if moduleRoot != "" && !fileExists(filepath.Join(dir, "go.mod")) {
+			add(CodeConflict, fmt.Sprintf("Output directory %s is inside the Go module in %s, choose a repository mode "+
+				"(--repo-mode workspace, package or standalone) or another directory.", config.OutputDir, moduleRoot))
+		}
+	case RepoWorkspace:
+		if findUp(filepath.Dir(dir), "go.mod", "go.work", ".git") == "" {
+			add(CodeConflict, "The workspace repository mode needs an output directory inside a Go module, go.work or git repository.")
+		}
+	case RepoPackage:
+		if moduleRoot == "" {
+			add(CodeConflict, "The package repository mode needs an output directory inside a Go module.")
+			break
+		}
+		data, err := os.ReadFile(filepath.Join(moduleRoot, "go.mod"))
+		if err != nil {
+			break
+		}
+		rel, _ := filepath.Rel(moduleRoot, dir)
+		if want := path.Join(modfile.ModulePath(data), filepath.ToSlash(rel)); config.ModulePath != want {
+			validationErrors = append(validationErrors, ValidationError{
+				Field:   "module_path",
+				Code:    CodeConflict,
+				Message: fmt.Sprintf("In the package repository mode the module path must be the import path of the output directory, %s.", want),
+			})
+		}
+	}
+	return validationErrors
+}
+
+// findUp returns the nearest directory from dir upwards that holds one of names, or ""
+func findUp(dir string, names ...string) string {
+	for {
+		for _, name := range names {
+			if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+				return dir
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// isWritableDir reports whether files can be created in dir
+func isWritableDir(dir string) bool {
+	f, err := os.CreateTemp(dir, ".goback-write-check-*")
+	if err != nil {
+		return false
+	}
+	f.Close()
+	_ = os.Remove(f.Name())
+	return true
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// sentence turns an error into a message: capitalized and ending with a period
+func sentence(err error) string {
+	message := err.Error()
+	if message == "" {
+		return message
+	}
+	return strings.ToUpper(message[:1]) + message[1:] + "."
 }
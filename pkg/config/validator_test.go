@@ -0,0 +1,54 @@
+// pkg/config/validator_test.go
+
+package config
+
+import "testing"
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "gin", 3},
+		{"gin", "", 3},
+		{"gin", "gin", 0},
+		{"gni", "gin", 2},
+		{"postgres", "postgresql", 2},
+		{"mysql", "mysq", 1},
+		{"kitten", "sitting", 3},
+		{"héllo", "hello", 1},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSuggestChoice(t *testing.T) {
+	frameworks := []string{"fiber", "gin", "chi", "echo"}
+	databases := []string{"postgresql", "mysql", "sqlite"}
+
+	tests := []struct {
+		value   string
+		choices []string
+		want    string
+	}{
+		{"gin", frameworks, "gin"},
+		{"GIN", frameworks, "gin"},
+		{"fiberr", frameworks, "fiber"},
+		{"ech", frameworks, "echo"},
+		{"django", frameworks, ""},
+		{"postgres", databases, "postgresql"},
+		{"sqllite", databases, "sqlite"},
+		{"mongodb", databases, ""},
+		{"", databases, ""},
+		{"gin", nil, ""},
+	}
+	for _, tt := range tests {
+		if got := SuggestChoice(tt.value, tt.choices); got != tt.want {
+			t.Errorf("SuggestChoice(%q, %v) = %q, want %q", tt.value, tt.choices, got, tt.want)
+		}
+	}
+}
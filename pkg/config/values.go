@@ -0,0 +1,110 @@
+// pkg/config/values.go
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadValuesFile reads template values from a YAML (or JSON) file
+func LoadValuesFile(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse values file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// SetValue applies a key=value expression to values. Dotted keys create nested
+// maps and the value is typed like a YAML scalar, so port=9090 is a number and
+// debug=true is a boolean.
+func SetValue(values map[string]interface{}, expr string) error {
+	key, raw, ok := strings.Cut(expr, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return fmt.Errorf("invalid value %q, expected key=value", expr)
+	}
+
+	var value interface{}
+	if err := yaml.Unmarshal([]byte(raw), &value); err != nil || value == nil || isCollection(value) {
+		value = raw
+	}
+
+	parts := strings.Split(key, ".")
+	current := values
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := current[part].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			current[part] = next
+		}
+		current = next
+	}
+	current[parts[len(parts)-1]] = value
+	return nil
+}
+
+// MergeValues deep merges src into dst, with src taking precedence
+func MergeValues(dst, src map[string]interface{}) map[string]interface{} {
+	if dst == nil {
+		dst = map[string]interface{}{}
+	}
+	for key, value := range src {
+		srcMap, srcIsMap := value.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			dst[key] = MergeValues(dstMap, srcMap)
+			continue
+		}
+		dst[key] = value
+	}
+	return dst
+}
+
+// Value returns the template value at a dotted key, or def when it is not set.
+// Keys match case-insensitively, as values read through viper are lowercased.
+func (c *ProjectConfig) Value(key string, def interface{}) interface{} {
+	var current interface{} = c.Values
+	for _, part := range strings.Split(key, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return def
+		}
+		if current, ok = lookupKey(m, part); !ok {
+			return def
+		}
+	}
+	if current == nil {
+		return def
+	}
+	return current
+}
+
+func lookupKey(m map[string]interface{}, key string) (interface{}, bool) {
+	if value, ok := m[key]; ok {
+		return value, true
+	}
+	for k, value := range m {
+		if strings.EqualFold(k, key) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+func isCollection(value interface{}) bool {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return true
+	}
+	return false
+}
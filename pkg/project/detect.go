@@ -0,0 +1,352 @@
+// pkg/project/detect.go
+
+package project
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/NarmadaWeb/goback/pkg/config"
+	"golang.org/x/mod/module"
+)
+
+// ErrNotAProject is returned when a directory has no go.mod file
+var ErrNotAProject = errors.New("no go.mod found, run this command inside a generated project")
+
+// GoMod holds the parts of a go.mod file needed for detection
+type GoMod struct {
+	Module    string
+	GoVersion string
+	Toolchain string
+	Requires  []string
+}
+
+// Require reports whether the module requires the given module path
+func (m *GoMod) Require(modulePath string) bool {
+	for _, r := range m.Requires {
+		if r == modulePath {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadGoMod reads the module path, Go version and required modules from dir/go.mod
+func ReadGoMod(dir string) (*GoMod, error) {
+	file, err := os.Open(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotAProject
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	mod := &GoMod{}
+	inRequire := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch {
+		case inRequire && fields[0] == ")":
+			inRequire = false
+		case inRequire:
+			mod.Requires = append(mod.Requires, fields[0])
+		case fields[0] == "module" && len(fields) > 1:
+			mod.Module = strings.Trim(fields[1], `"`)
+		case fields[0] == "go" && len(fields) > 1:
+			mod.GoVersion = fields[1]
+		case fields[0] == "toolchain" && len(fields) > 1:
+			mod.Toolchain = strings.TrimPrefix(fields[1], "go")
+		case fields[0] == "require" && len(fields) > 1 && fields[1] == "(":
+			inRequire = true
+		case fields[0] == "require" && len(fields) > 1:
+			mod.Requires = append(mod.Requires, fields[1])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if mod.Module == "" {
+		return nil, fmt.Errorf("go.mod in %s has no module directive", dir)
+	}
+	return mod, nil
+}
+
+// ModuleName returns the last element of a module path without its major
+// version suffix, e.g. billing for example.com/billing/v2
+func ModuleName(modulePath string) string {
+	prefix, _, ok := module.SplitPathVersion(modulePath)
+	if !ok || prefix == "" {
+		prefix = modulePath
+	}
+	return path.Base(prefix)
+}
+
+// Detect inspects a generated project and reconstructs its configuration from
+// the go.mod file, the environment files and the directory layout.
+func Detect(dir string) (*config.ProjectConfig, error) {
+	inspection, err := detect(dir)
+	if err != nil {
+		return nil, err
+	}
+	if missing := inspection.Missing(); len(missing) > 0 {
+		return inspection.Config, fmt.Errorf("could not detect the project's %s", strings.Join(missing, ", "))
+	}
+	return inspection.Config, nil
+}
+
+// Inspection describes how a project was built
+type Inspection struct {
+	Config *config.ProjectConfig
+	// Recorded reports whether the configuration comes from the project manifest
+	Recorded bool
+	// Evidence explains what each detected setting was derived from, keyed by
+	// kind, framework, database, tool, architecture and devops
+	Evidence map[string]string
+}
+
+// Missing returns the settings that could not be detected
+func (i *Inspection) Missing() []string {
+	var missing []string
+	if i.Config.Framework == "" && i.Config.Kind.HasAPI() {
+		missing = append(missing, "framework")
+	}
+	if i.Config.Database == "" {
+		missing = append(missing, "database")
+	}
+	if i.Config.Tool == "" {
+		missing = append(missing, "tool")
+	}
+	if i.Config.Architecture == "" {
+		missing = append(missing, "architecture")
+	}
+	return missing
+}
+
+// Inspect reports how the project in dir was built. The configuration recorded
+// by goback is used when present, otherwise it is detected from the project files.
+func Inspect(dir string) (*Inspection, error) {
+	m, err := LoadManifest(dir)
+	if err == nil {
+		return &Inspection{Config: &m.Config, Recorded: true, Evidence: map[string]string{}}, nil
+	}
+	if !errors.Is(err, ErrNoManifest) {
+		return nil, err
+	}
+	return detect(dir)
+}
+
+func detect(dir string) (*Inspection, error) {
+	mod, err := ReadGoMod(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &config.ProjectConfig{
+		ProjectName: ModuleName(mod.Module),
+		ModulePath:  mod.Module,
+		OutputDir:   absDir,
+		GoVersion:   mod.GoVersion,
+	}
+	if mod.Toolchain != "" {
+		cfg.GoVersion = mod.Toolchain
+	}
+	evidence := map[string]string{}
+	cfg.Kind, evidence["kind"] = detectKind(dir)
+	cfg.Framework, evidence["framework"] = detectFramework(mod)
+	cfg.Database, evidence["database"] = detectDatabase(dir, mod)
+	cfg.Tool, evidence["tool"] = detectTool(dir, mod)
+	cfg.Architecture, evidence["architecture"] = detectArchitecture(dir)
+	cfg.DevOps, evidence["devops"] = detectDevOps(dir)
+
+	return &Inspection{Config: cfg, Evidence: evidence}, nil
+}
+
+func detectKind(dir string) (config.ProjectKind, string) {
+	has := func(name string) bool { return dirExists(filepath.Join(dir, "cmd", name)) }
+	switch {
+	case has("api") && has("worker"):
+		return config.KindAPIWorker, "cmd/api/ and cmd/worker/ exist"
+	case has("worker"):
+		return config.KindWorker, "cmd/worker/ exists"
+	case has("cli"):
+		return config.KindCLI, "cmd/cli/ exists"
+	case has("api"):
+		return config.KindAPI, "cmd/api/ exists"
+	}
+	return "", ""
+}
+
+func detectFramework(mod *GoMod) (config.FrameworkChoice, string) {
+	frameworks := []struct {
+		module    string
+		framework config.FrameworkChoice
+	}{
+		{"github.com/gofiber/fiber/v2", config.FrameworkFiber},
+		{"github.com/gin-gonic/gin", config.FrameworkGin},
+		{"github.com/go-chi/chi/v5", config.FrameworkChi},
+		{"github.com/labstack/echo/v4", config.FrameworkEcho},
+	}
+	for _, f := range frameworks {
+		if mod.Require(f.module) {
+			return f.framework, "go.mod requires " + f.module
+		}
+	}
+	return "", ""
+}
+
+func detectDatabase(dir string, mod *GoMod) (config.DatabaseChoice, string) {
+	// The environment files name the database explicitly
+	for _, name := range []string{".env", ".env.example"} {
+		lines, err := readLines(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		for _, line := range lines {
+			switch {
+			case line == "DB_TYPE=postgres" || line == "DB_TYPE=postgresql":
+				return config.DatabasepostgresQL, name + " sets " + line
+			case line == "DB_TYPE=mysql":
+				return config.DatabaseMySQL, name + " sets " + line
+			case strings.HasPrefix(line, "DB_PATH="):
+				return config.DatabaseSQLite, name + " sets DB_PATH"
+			}
+		}
+	}
+
+	drivers := []struct {
+		module   string
+		database config.DatabaseChoice
+	}{
+		{"github.com/lib/pq", config.DatabasepostgresQL},
+		{"github.com/jackc/pgx/v5", config.DatabasepostgresQL},
+		{"github.com/jackc/pgx/v4", config.DatabasepostgresQL},
+		{"gorm.io/driver/postgres", config.DatabasepostgresQL},
+		{"github.com/go-sql-driver/mysql", config.DatabaseMySQL},
+		{"gorm.io/driver/mysql", config.DatabaseMySQL},
+		{"github.com/mattn/go-sqlite3", config.DatabaseSQLite},
+		{"modernc.org/sqlite", config.DatabaseSQLite},
+		{"gorm.io/driver/sqlite", config.DatabaseSQLite},
+	}
+	for _, d := range drivers {
+		if mod.Require(d.module) {
+			return d.database, "go.mod requires " + d.module
+		}
+	}
+	return "", ""
+}
+
+func detectTool(dir string, mod *GoMod) (config.ToolChoice, string) {
+	switch {
+	case mod.Require("gorm.io/gorm"):
+		return config.ToolGorm, "go.mod requires gorm.io/gorm"
+	case mod.Require("github.com/jmoiron/sqlx"):
+		return config.ToolSqlx, "go.mod requires github.com/jmoiron/sqlx"
+	}
+	for _, name := range []string{"sqlc.yaml", "sqlc.yml", "sqlc.json"} {
+		if fileExists(filepath.Join(dir, name)) {
+			return config.ToolSqlc, name + " exists"
+		}
+	}
+	return "", ""
+}
+
+func detectArchitecture(dir string) (config.ArchitectureChoice, string) {
+	layouts := []struct {
+		dir          string
+		architecture config.ArchitectureChoice
+	}{
+		{"adapters/primary/http", config.ArchitectureHexagonal},
+		{"ports", config.ArchitectureHexagonal},
+		{"adapters", config.ArchitectureHexagonal},
+		{"domain/usecases", config.ArchitectureClean},
+		{"domain/services", config.ArchitectureDDD},
+		{"domain/repositories", config.ArchitectureDDD},
+		{"internal", config.ArchitectureSimple},
+	}
+	for _, l := range layouts {
+		if dirExists(filepath.Join(dir, filepath.FromSlash(l.dir))) {
+			return l.architecture, l.dir + "/ exists"
+		}
+	}
+	return "", ""
+}
+
+func detectDevOps(dir string) (config.DevOpsConfig, string) {
+	var devops config.DevOpsConfig
+	var found []string
+
+	layouts := []struct {
+		tool  string
+		paths []string
+	}{
+		{"helm", []string{"devops/helm", "charts", "helm"}},
+		{"terraform", []string{"devops/terraform", "terraform", "main.tf"}},
+		{"ansible", []string{"devops/ansible", "ansible", "playbook.yml"}},
+	}
+	for _, l := range layouts {
+		for _, p := range l.paths {
+			if !fileExists(filepath.Join(dir, filepath.FromSlash(p))) && !dirExists(filepath.Join(dir, filepath.FromSlash(p))) {
+				continue
+			}
+			devops.Tools = append(devops.Tools, l.tool)
+			found = append(found, p)
+			switch l.tool {
+			case "helm":
+				devops.Helm = true
+			case "terraform":
+				devops.Terraform = true
+			case "ansible":
+				devops.Ansible = true
+			}
+			break
+		}
+	}
+
+	devops.Enabled = len(devops.Tools) > 0
+	if !devops.Enabled {
+		return devops, ""
+	}
+	return devops, strings.Join(found, ", ") + " found"
+}
+
+func readLines(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		lines = append(lines, strings.TrimSpace(line))
+	}
+	return lines, nil
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,24 @@
+// pkg/project/detect_test.go
+
+package project
+
+import "testing"
+
+func TestModuleName(t *testing.T) {
+	tests := []struct {
+		modulePath, want string
+	}{
+		{"github.com/acme/billing", "billing"},
+		{"github.com/acme/billing/v2", "billing"},
+		{"example.com/billing/v10", "billing"},
+		{"gopkg.in/yaml.v3", "yaml"},
+		{"billing", "billing"},
+		{"github.com/acme/v2", "acme"},
+		{"github.com/acme/api-v2", "api-v2"},
+	}
+	for _, tt := range tests {
+		if got := ModuleName(tt.modulePath); got != tt.want {
+			t.Errorf("ModuleName(%q) = %q, want %q", tt.modulePath, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// pkg/project/drift.go
+
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// FileDrift describes how a file in a project differs from its templates
+type FileDrift struct {
+	Path string
+	// State compares the working tree with the file as it was generated
+	State FileState
+	// Upstream reports whether the templates now render the file differently
+	// than when it was generated
+	Upstream bool
+	// Current is the content in the working tree, nil when the file is missing
+	Current []byte
+	// Rendered is the content rendered by the current templates, nil when
+	// the templates no longer produce the file
+	Rendered []byte
+}
+
+// Status returns a short description of the drift
+func (d FileDrift) Status() string {
+	switch {
+	case d.State == FileUntracked:
+		return "added upstream"
+	case d.Upstream && d.Rendered == nil:
+		return "removed upstream"
+	case d.Upstream && d.State != FilePristine:
+		return d.State.String() + ", changed upstream"
+	case d.Upstream:
+		return "changed upstream"
+	default:
+		return d.State.String()
+	}
+}
+
+// Changed reports whether the working tree differs from the rendered templates
+func (d FileDrift) Changed() bool {
+	return (d.Current == nil) != (d.Rendered == nil) || string(d.Current) != string(d.Rendered)
+}
+
+// UnifiedDiff returns the unified diff from the rendered templates to the working tree
+func (d FileDrift) UnifiedDiff() (string, error) {
+	from, to := "a/"+d.Path, "b/"+d.Path
+	if d.Rendered == nil {
+		from = "/dev/null"
+	}
+	if d.Current == nil {
+		to = "/dev/null"
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(d.Rendered),
+		B:        splitLines(d.Current),
+		FromFile: from,
+		ToFile:   to,
+		Context:  3,
+	})
+}
+
+// Drift compares the project in dir with freshly rendered templates. Every file
+// that was either generated or is rendered now is reported, sorted by path.
+func (m *Manifest) Drift(dir string, rendered map[string][]byte) []FileDrift {
+	paths := make(map[string]bool, len(m.Files))
+	for path := range m.Files {
+		paths[path] = true
+	}
+	for path := range rendered {
+		paths[path] = true
+	}
+
+	drift := make([]FileDrift, 0, len(paths))
+	for path := range paths {
+		d := FileDrift{
+			Path:     path,
+			State:    m.State(dir, path),
+			Rendered: rendered[path],
+		}
+		if content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(path))); err == nil {
+			d.Current = content
+		}
+
+		recorded, tracked := m.Files[path]
+		_, stillRendered := rendered[path]
+		d.Upstream = !tracked || !stillRendered || HashContent(d.Rendered) != recorded
+
+		drift = append(drift, d)
+	}
+
+	sort.Slice(drift, func(i, j int) bool { return drift[i].Path < drift[j].Path })
+	return drift
+}
+
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	return difflib.SplitLines(strings.TrimSuffix(string(content), "\n"))
+}
@@ -0,0 +1,164 @@
+// pkg/project/manifest.go
+
+package project
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/NarmadaWeb/goback/pkg/config"
+)
+
+// ManifestPath is the location of the project metadata, relative to the project root
+const ManifestPath = ".goback/project.json"
+
+const manifestVersion = 1
+
+// ErrNoManifest is returned when a project has no recorded metadata
+var ErrNoManifest = errors.New("no " + ManifestPath + " found, the project was not generated by this version of goback")
+
+// Manifest is the metadata goback records in a generated project: the
+// configuration it was generated with and a hash of every generated file.
+type Manifest struct {
+	Version     int                  `json:"version"`
+	Config      config.ProjectConfig `json:"config"`
+	Files       map[string]string    `json:"files"`
+	GeneratedAt time.Time            `json:"generated_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+}
+
+// FileState describes how a file on disk relates to the generated version
+type FileState int
+
+// File states
+const (
+	FileUntracked FileState = iota // not generated by goback
+	FilePristine                   // unchanged since it was generated
+	FileModified                   // changed by the user
+	FileDeleted                    // generated but removed by the user
+)
+
+// String returns the name of the state
+func (s FileState) String() string {
+	switch s {
+	case FilePristine:
+		return "pristine"
+	case FileModified:
+		return "modified"
+	case FileDeleted:
+		return "deleted"
+	default:
+		return "untracked"
+	}
+}
+
+// NewManifest creates an empty manifest for cfg
+func NewManifest(cfg *config.ProjectConfig) *Manifest {
+	now := time.Now()
+	return &Manifest{
+		Version:     manifestVersion,
+		Config:      *cfg,
+		Files:       map[string]string{},
+		GeneratedAt: now,
+		UpdatedAt:   now,
+	}
+}
+
+// LoadManifest reads the manifest of the project in dir. The recorded output
+// directory is replaced by dir, as the project may have been moved.
+func LoadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestPath))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNoManifest
+		}
+		return nil, err
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ManifestPath, err)
+	}
+	if m.Files == nil {
+		m.Files = map[string]string{}
+	}
+	// Manifests written before the creation time was recorded
+	if m.Config.CreatedAt.IsZero() {
+		m.Config.CreatedAt = m.GeneratedAt
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	m.Config.OutputDir = absDir
+	return &m, nil
+}
+
+// Save writes the manifest to the project in dir
+func (m *Manifest) Save(dir string) error {
+	m.UpdatedAt = time.Now()
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, ManifestPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Record stores the hash of generated content for a slash separated path
+func (m *Manifest) Record(path string, content []byte) {
+	m.Files[path] = HashContent(content)
+}
+
+// State compares the file at path in the project in dir with its recorded hash
+func (m *Manifest) State(dir, path string) FileState {
+	return fileState(m.Files, dir, path)
+}
+
+// fileState compares the file at path in dir with its hash in files
+func fileState(files map[string]string, dir, path string) FileState {
+	recorded, tracked := files[path]
+
+	content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+	switch {
+	case !tracked:
+		return FileUntracked
+	case err != nil:
+		return FileDeleted
+	case HashContent(content) == recorded:
+		return FilePristine
+	default:
+		return FileModified
+	}
+}
+
+// HashContent returns the hash recorded for generated content
+func HashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Load returns the configuration of the project in dir, preferring the
+// recorded manifest and falling back to detection from the project files.
+func Load(dir string) (*config.ProjectConfig, error) {
+	m, err := LoadManifest(dir)
+	if err == nil {
+		return &m.Config, nil
+	}
+	if !errors.Is(err, ErrNoManifest) {
+		return nil, err
+	}
+	return Detect(dir)
+}
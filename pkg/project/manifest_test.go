@@ -0,0 +1,75 @@
+// pkg/project/manifest_test.go
+
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NarmadaWeb/goback/pkg/config"
+)
+
+func TestManifestState(t *testing.T) {
+	tests := []struct {
+		name      string
+		generated string // recorded content, "" when the file is not recorded
+		onDisk    string // content on disk, "" when the file does not exist
+		want      FileState
+	}{
+		{"pristine", "package main\n", "package main\n", FilePristine},
+		{"modified", "package main\n", "package main\n\nfunc main() {}\n", FileModified},
+		{"deleted", "package main\n", "", FileDeleted},
+		{"untracked", "", "package main\n", FileUntracked},
+		{"untracked and missing", "", "", FileUntracked},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			const path = "internal/app/main.go"
+
+			m := NewManifest(&config.ProjectConfig{ProjectName: "shop"})
+			if tt.generated != "" {
+				m.Record(path, []byte(tt.generated))
+			}
+			if tt.onDisk != "" {
+				file := filepath.Join(dir, filepath.FromSlash(path))
+				if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(file, []byte(tt.onDisk), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if got := m.State(dir, path); got != tt.want {
+				t.Errorf("State() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManifestSaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := LoadManifest(dir); err != ErrNoManifest {
+		t.Fatalf("LoadManifest() without a manifest error = %v, want ErrNoManifest", err)
+	}
+
+	m := NewManifest(&config.ProjectConfig{ProjectName: "shop", Framework: config.FrameworkChi, OutputDir: "/elsewhere"})
+	m.Record("go.mod", []byte("module example.com/shop\n"))
+	if err := m.Save(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Config.Framework != config.FrameworkChi || loaded.Files["go.mod"] != m.Files["go.mod"] {
+		t.Errorf("LoadManifest() = %+v, want the saved manifest", loaded)
+	}
+	// The project may have been moved since it was generated
+	if abs, _ := filepath.Abs(dir); loaded.Config.OutputDir != abs {
+		t.Errorf("recorded output directory = %s, want %s", loaded.Config.OutputDir, abs)
+	}
+}
@@ -0,0 +1,161 @@
+// pkg/project/platform.go
+
+package project
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/NarmadaWeb/goback/pkg/config"
+)
+
+// PlatformPath is the location of the monorepo metadata, relative to the platform root
+const PlatformPath = ".goback/platform.json"
+
+// ServicesDir holds the services of a platform
+const ServicesDir = "services"
+
+// FirstServicePort is the port of the first service; later services count up from it
+const FirstServicePort = 8080
+
+// ErrNoPlatform is returned when a directory is not the root of a monorepo platform
+var ErrNoPlatform = errors.New("no " + PlatformPath + " found, run this command in a platform created with 'goback new --monorepo'")
+
+// Platform is the metadata of a monorepo: its root module, its services and a
+// hash of every platform file goback generated
+type Platform struct {
+	Name       string            `json:"name"`
+	ModulePath string            `json:"module_path"`
+	GoVersion  string            `json:"go_version,omitempty"`
+	Services   []PlatformService `json:"services"`
+	Files      map[string]string `json:"files"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+}
+
+// PlatformService is a service of a platform
+type PlatformService struct {
+	Name         string                    `json:"name"`
+	Path         string                    `json:"path"`
+	Kind         config.ProjectKind        `json:"kind,omitempty"`
+	Framework    config.FrameworkChoice    `json:"framework,omitempty"`
+	Database     config.DatabaseChoice     `json:"database"`
+	Architecture config.ArchitectureChoice `json:"architecture"`
+	Port         int                       `json:"port"`
+}
+
+// NewPlatform creates the metadata of a platform without services
+func NewPlatform(name, modulePath, goVersion string) *Platform {
+	now := time.Now()
+	return &Platform{
+		Name:       name,
+		ModulePath: modulePath,
+		GoVersion:  goVersion,
+		Services:   []PlatformService{},
+		Files:      map[string]string{},
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// LoadPlatform reads the metadata of the platform in dir
+func LoadPlatform(dir string) (*Platform, error) {
+	data, err := os.ReadFile(filepath.Join(dir, PlatformPath))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNoPlatform
+		}
+		return nil, err
+	}
+
+	var p Platform
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", PlatformPath, err)
+	}
+	if p.Files == nil {
+		p.Files = map[string]string{}
+	}
+	return &p, nil
+}
+
+// Save writes the metadata to the platform in dir
+func (p *Platform) Save(dir string) error {
+	p.UpdatedAt = time.Now()
+
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, PlatformPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// State compares the platform file at path in dir with its recorded hash
+func (p *Platform) State(dir, path string) FileState {
+	return fileState(p.Files, dir, path)
+}
+
+// Service returns the service with the given name, or nil
+func (p *Platform) Service(name string) *PlatformService {
+	for i := range p.Services {
+		if p.Services[i].Name == name {
+			return &p.Services[i]
+		}
+	}
+	return nil
+}
+
+// Deployed returns the services that run as containers; CLI tools are left out
+func (p *Platform) Deployed() []PlatformService {
+	var deployed []PlatformService
+	for _, service := range p.Services {
+		if !service.Kind.HasCLI() {
+			deployed = append(deployed, service)
+		}
+	}
+	return deployed
+}
+
+// NextPort returns the port for a new service, one above the highest port in use
+func (p *Platform) NextPort() int {
+	port := FirstServicePort
+	for _, service := range p.Services {
+		if service.Port >= port {
+			port = service.Port + 1
+		}
+	}
+	return port
+}
+
+// AddService records the service of cfg, generated in the services directory
+func (p *Platform) AddService(cfg *config.ProjectConfig, port int) {
+	p.Services = append(p.Services, PlatformService{
+		Name:         cfg.ProjectName,
+		Path:         ServicesDir + "/" + cfg.ProjectName,
+		Kind:         cfg.Kind,
+		Framework:    cfg.Framework,
+		Database:     cfg.Database,
+		Architecture: cfg.Architecture,
+		Port:         port,
+	})
+}
+
+// GoLanguageVersion returns the language version for the go directives, e.g. 1.22
+func (p *Platform) GoLanguageVersion() string {
+	cfg := config.ProjectConfig{GoVersion: p.GoVersion}
+	return cfg.GoLanguageVersion()
+}
+
+// GoToolchainVersion returns the full release used for the builder images, e.g. 1.22.3
+func (p *Platform) GoToolchainVersion() string {
+	cfg := config.ProjectConfig{GoVersion: p.GoVersion}
+	return cfg.GoToolchainVersion()
+}
@@ -0,0 +1,199 @@
+// pkg/project/repo.go
+
+package project
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/NarmadaWeb/goback/pkg/config"
+	"golang.org/x/mod/modfile"
+)
+
+// Repo describes the Go module, Go workspace and git repository around a directory
+type Repo struct {
+	// ModuleRoot is the directory of the nearest enclosing go.mod, "" when there is none
+	ModuleRoot string
+	// Module is the module path declared in ModuleRoot
+	Module string
+	// WorkFile is the nearest enclosing go.work, "" when there is none
+	WorkFile string
+	// GitRoot is the top directory of the enclosing git repository, "" when there is none
+	GitRoot string
+}
+
+// DetectRepo finds the module, workspace and git repository that enclose dir.
+// dir itself is not looked at, so a project regenerated in place is not
+// inside its own module.
+func DetectRepo(dir string) (*Repo, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := &Repo{}
+	for current := filepath.Dir(abs); ; {
+		if repo.ModuleRoot == "" && fileExists(filepath.Join(current, "go.mod")) {
+			mod, err := ReadGoMod(current)
+			if err != nil {
+				return nil, err
+			}
+			repo.ModuleRoot, repo.Module = current, mod.Module
+		}
+		if repo.WorkFile == "" && fileExists(filepath.Join(current, "go.work")) {
+			repo.WorkFile = filepath.Join(current, "go.work")
+		}
+		if _, err := os.Stat(filepath.Join(current, ".git")); repo.GitRoot == "" && err == nil {
+			repo.GitRoot = current
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			break
+		}
+		current = parent
+	}
+	return repo, nil
+}
+
+// Enclosed reports whether there is a module, workspace or git repository around the directory
+func (r *Repo) Enclosed() bool {
+	return r.ModuleRoot != "" || r.WorkFile != "" || r.GitRoot != ""
+}
+
+// Modes returns the repository modes a project can be generated in; the
+// package mode needs an enclosing module
+func (r *Repo) Modes() []config.RepoMode {
+	modes := []config.RepoMode{config.RepoWorkspace, config.RepoStandalone}
+	if r.ModuleRoot != "" {
+		modes = []config.RepoMode{config.RepoWorkspace, config.RepoPackage, config.RepoStandalone}
+	}
+	return modes
+}
+
+// PackagePath returns the import path of dir inside the enclosing module
+func (r *Repo) PackagePath(dir string) (string, error) {
+	if r.ModuleRoot == "" {
+		return "", fmt.Errorf("%s is not inside a Go module", dir)
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(r.ModuleRoot, abs)
+	if err != nil {
+		return "", err
+	}
+	return path.Join(r.Module, filepath.ToSlash(rel)), nil
+}
+
+// WorkspaceFile returns the go.work the repository uses: the enclosing one,
+// or a new one next to the enclosing go.mod or at the top of the git repository
+func (r *Repo) WorkspaceFile() string {
+	switch {
+	case r.WorkFile != "":
+		return r.WorkFile
+	case r.ModuleRoot != "":
+		return filepath.Join(r.ModuleRoot, "go.work")
+	case r.GitRoot != "":
+		return filepath.Join(r.GitRoot, "go.work")
+	}
+	return ""
+}
+
+// WorkspaceUses reports whether the enclosing go.work uses the module in dir.
+// The go command refuses to build a module inside a workspace that does not
+// list it, unless GOWORK=off.
+func (r *Repo) WorkspaceUses(dir string) bool {
+	if r.WorkFile == "" {
+		return false
+	}
+	data, err := os.ReadFile(r.WorkFile)
+	if err != nil {
+		return false
+	}
+	work, err := modfile.ParseWork(r.WorkFile, data, nil)
+	if err != nil {
+		return false
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	for _, use := range work.Use {
+		if filepath.Clean(filepath.Join(filepath.Dir(r.WorkFile), filepath.FromSlash(use.Path))) == abs {
+			return true
+		}
+	}
+	return false
+}
+
+// UseModule adds the module in dir to the repository's go.work and returns
+// the file's path. A new go.work also uses the enclosing module, so that it
+// keeps building; the go version is raised to goVersion when it is older.
+func (r *Repo) UseModule(dir, goVersion string) (string, error) {
+	workPath := r.WorkspaceFile()
+	if workPath == "" {
+		return "", fmt.Errorf("%s is not inside a Go module, go.work or git repository", dir)
+	}
+	workDir := filepath.Dir(workPath)
+
+	var work *modfile.WorkFile
+	data, err := os.ReadFile(workPath)
+	switch {
+	case err == nil:
+		if work, err = modfile.ParseWork(workPath, data, nil); err != nil {
+			return "", err
+		}
+	case os.IsNotExist(err):
+		work = &modfile.WorkFile{Syntax: &modfile.FileSyntax{}}
+		if r.ModuleRoot != "" {
+			if err := addUse(work, workDir, r.ModuleRoot); err != nil {
+				return "", err
+			}
+		}
+	default:
+		return "", err
+	}
+
+	if work.Go == nil || config.CompareGoVersions(work.Go.Version, goVersion) < 0 {
+		if err := work.AddGoStmt(goVersion); err != nil {
+			return "", err
+		}
+	}
+	if err := addUse(work, workDir, dir); err != nil {
+		return "", err
+	}
+	work.Cleanup()
+
+	if err := os.WriteFile(workPath, modfile.Format(work.Syntax), 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", workPath, err)
+	}
+	return workPath, nil
+}
+
+// addUse adds a use directive for dir, relative to the workspace directory,
+// unless the workspace already uses it
+func addUse(work *modfile.WorkFile, workDir, dir string) error {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(workDir, abs)
+	if err != nil {
+		return err
+	}
+	usePath := "./" + filepath.ToSlash(rel)
+	if rel == "." {
+		usePath = "."
+	}
+
+	for _, use := range work.Use {
+		if path.Clean(use.Path) == path.Clean(usePath) {
+			return nil
+		}
+	}
+	return work.AddUse(usePath, "")
+}
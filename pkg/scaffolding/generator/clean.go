@@ -0,0 +1,188 @@
+// pkg/scaffolding/generator/clean.go
+
+package generator
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/NarmadaWeb/goback/internal/utils"
+	"github.com/NarmadaWeb/goback/pkg/project"
+)
+
+// Cleaner removes the files goback generated in a project, using the hashes
+// recorded in the manifest. Only files unchanged since generation are
+// removed; modified files are kept and reported. Directories left empty are
+// removed too, and DevOps tools whose files are all gone are dropped from the
+// recorded configuration. When no generated file is left, the manifest is
+// removed as well, and .goback when nothing else is in it.
+type Cleaner struct {
+	Dir      string
+	Manifest *project.Manifest
+	// Match selects the generated files to clean, by slash separated path; nil selects all
+	Match  func(path string) bool
+	DryRun bool
+
+	removed     []string
+	kept        []string
+	missing     []string
+	tools       []string
+	uninstalled bool
+}
+
+// NewCleaner creates a cleaner for the project in dir
+func NewCleaner(dir string, manifest *project.Manifest) *Cleaner {
+	return &Cleaner{Dir: dir, Manifest: manifest}
+}
+
+// Removed returns the files removed, or that would be removed in a dry run
+func (c *Cleaner) Removed() []string {
+	return c.removed
+}
+
+// Kept returns the files kept because they were modified since generation
+func (c *Cleaner) Kept() []string {
+	return c.kept
+}
+
+// Missing returns the generated files that were already deleted
+func (c *Cleaner) Missing() []string {
+	return c.missing
+}
+
+// Tools returns the DevOps tools dropped from the configuration
+func (c *Cleaner) Tools() []string {
+	return c.tools
+}
+
+// Uninstalled reports whether the manifest was removed as no generated file is left
+func (c *Cleaner) Uninstalled() bool {
+	return c.uninstalled
+}
+
+// Clean removes the selected generated files that are unchanged
+func (c *Cleaner) Clean() error {
+	m := c.Manifest
+	paths := make([]string, 0, len(m.Files))
+	for p := range m.Files {
+		if c.Match == nil || c.Match(p) {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		switch m.State(c.Dir, p) {
+		case project.FilePristine:
+			c.removed = append(c.removed, p)
+		case project.FileModified:
+			c.kept = append(c.kept, p)
+		case project.FileDeleted:
+			c.missing = append(c.missing, p)
+		}
+	}
+	c.dropTools()
+	c.uninstalled = len(m.Files) == len(c.removed)+len(c.missing)
+	if c.DryRun {
+		return nil
+	}
+
+	for _, p := range c.removed {
+		if err := utils.DeleteFile(filepath.Join(c.Dir, filepath.FromSlash(p))); err != nil {
+			return err
+		}
+		delete(m.Files, p)
+	}
+	for _, p := range c.missing {
+		delete(m.Files, p)
+	}
+	if err := c.removeEmptyDirs(); err != nil {
+		return err
+	}
+	if c.uninstalled {
+		// Only the manifest; other files the user keeps in .goback stay
+		manifestPath := filepath.Join(c.Dir, filepath.FromSlash(project.ManifestPath))
+		if err := utils.DeleteFile(manifestPath); err != nil {
+			return err
+		}
+		return deleteEmptyDir(filepath.Dir(manifestPath))
+	}
+	return nil
+}
+
+// dropTools removes the DevOps tools that have no generated file left from the configuration
+func (c *Cleaner) dropTools() {
+	gone := map[string]bool{}
+	for _, p := range append(slices.Clone(c.removed), c.missing...) {
+		gone[p] = true
+	}
+
+	devops := &c.Manifest.Config.DevOps
+	var tools []string
+	for _, tool := range devops.Tools {
+		prefix := devopsDir + "/" + tool + "/"
+		cleaned, left := false, false
+		for p := range c.Manifest.Files {
+			if strings.HasPrefix(p, prefix) {
+				cleaned = cleaned || gone[p]
+				left = left || !gone[p]
+			}
+		}
+		if cleaned && !left {
+			c.tools = append(c.tools, tool)
+			continue
+		}
+		tools = append(tools, tool)
+	}
+	if len(c.tools) == 0 || c.DryRun {
+		return
+	}
+
+	devops.Tools = tools
+	devops.Enabled = len(tools) > 0
+	for _, tool := range c.tools {
+		switch tool {
+		case helmDir:
+			devops.Helm = false
+		case "terraform":
+			devops.Terraform = false
+		case ansibleDir:
+			devops.Ansible = false
+		}
+	}
+}
+
+// removeEmptyDirs removes the directories of the removed and missing files that are left empty
+func (c *Cleaner) removeEmptyDirs() error {
+	dirs := map[string]bool{}
+	for _, p := range append(slices.Clone(c.removed), c.missing...) {
+		for dir := path.Dir(p); dir != "."; dir = path.Dir(dir) {
+			dirs[dir] = true
+		}
+	}
+	sorted := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		sorted = append(sorted, dir)
+	}
+	// Deepest first, so that parents are empty when they are reached
+	sort.Slice(sorted, func(i, j int) bool { return strings.Count(sorted[i], "/") > strings.Count(sorted[j], "/") })
+
+	for _, dir := range sorted {
+		if err := deleteEmptyDir(filepath.Join(c.Dir, filepath.FromSlash(dir))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteEmptyDir deletes dir when it has no entries
+func deleteEmptyDir(dir string) error {
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) == 0 {
+		return utils.DeleteDir(dir)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+// pkg/scaffolding/generator/clean_test.go
+
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/NarmadaWeb/goback/pkg/config"
+	"github.com/NarmadaWeb/goback/pkg/project"
+)
+
+func TestCleaner(t *testing.T) {
+	tests := []struct {
+		name string
+		// generated files and their recorded content
+		generated map[string]string
+		// files on disk; generated files not listed here are deleted
+		onDisk map[string]string
+		tools  []string
+		match  func(path string) bool
+
+		wantRemoved, wantKept, wantMissing, wantTools []string
+		wantUninstalled                               bool
+		// wantLeft are the files on disk after cleaning, wantGone the paths that must not exist
+		wantLeft, wantGone []string
+	}{
+		{
+			name:            "pristine removed",
+			generated:       map[string]string{"internal/app/app.go": "package app\n", "go.mod": "module x\n"},
+			onDisk:          map[string]string{"internal/app/app.go": "package app\n", "go.mod": "module x\n"},
+			wantRemoved:     []string{"go.mod", "internal/app/app.go"},
+			wantUninstalled: true,
+			wantGone:        []string{"internal", project.ManifestPath, ".goback"},
+		},
+		{
+			name:        "modified kept",
+			generated:   map[string]string{"main.go": "package main\n", "internal/app/app.go": "package app\n"},
+			onDisk:      map[string]string{"main.go": "package main // changed\n", "internal/app/app.go": "package app\n"},
+			wantRemoved: []string{"internal/app/app.go"},
+			wantKept:    []string{"main.go"},
+			wantLeft:    []string{"main.go", project.ManifestPath},
+			wantGone:    []string{"internal"},
+		},
+		{
+			name:            "missing and untracked",
+			generated:       map[string]string{"internal/app/app.go": "package app\n"},
+			onDisk:          map[string]string{"internal/app/notes.md": "mine\n"},
+			wantMissing:     []string{"internal/app/app.go"},
+			wantUninstalled: true,
+			wantLeft:        []string{"internal/app/notes.md"},
+			wantGone:        []string{project.ManifestPath},
+		},
+		{
+			name:        "partial match",
+			generated:   map[string]string{"main.go": "package main\n", "devops/helm/Chart.yaml": "name: x\n"},
+			onDisk:      map[string]string{"main.go": "package main\n", "devops/helm/Chart.yaml": "name: x\n"},
+			tools:       []string{"helm"},
+			match:       func(path string) bool { return strings.HasPrefix(path, "devops/helm/") },
+			wantRemoved: []string{"devops/helm/Chart.yaml"},
+			wantTools:   []string{"helm"},
+			wantLeft:    []string{"main.go", project.ManifestPath},
+			wantGone:    []string{"devops"},
+		},
+		{
+			name: "devops tool dropped only when all its files are gone",
+			generated: map[string]string{
+				"devops/helm/Chart.yaml":       "name: x\n",
+				"devops/helm/values.yaml":      "port: 8080\n",
+				"devops/terraform/main.tf":     "# main\n",
+				"devops/terraform/variable.tf": "# vars\n",
+			},
+			onDisk: map[string]string{
+				"devops/helm/Chart.yaml":       "name: x\n",
+				"devops/terraform/main.tf":     "# main\n",
+				"devops/terraform/variable.tf": "# vars, changed\n",
+			},
+			tools:       []string{"helm", "terraform"},
+			wantRemoved: []string{"devops/helm/Chart.yaml", "devops/terraform/main.tf"},
+			wantKept:    []string{"devops/terraform/variable.tf"},
+			wantMissing: []string{"devops/helm/values.yaml"},
+			wantTools:   []string{"helm"},
+			wantLeft:    []string{"devops/terraform/variable.tf", project.ManifestPath},
+			wantGone:    []string{"devops/helm"},
+		},
+		{
+			name:            ".goback kept when it holds other files",
+			generated:       map[string]string{"main.go": "package main\n"},
+			onDisk:          map[string]string{"main.go": "package main\n", ".goback/notes.md": "mine\n"},
+			wantRemoved:     []string{"main.go"},
+			wantUninstalled: true,
+			wantLeft:        []string{".goback/notes.md"},
+			wantGone:        []string{"main.go", project.ManifestPath},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			manifest := project.NewManifest(&config.ProjectConfig{
+				ProjectName: "shop",
+				DevOps:      config.DevOpsConfig{Enabled: len(tt.tools) > 0, Tools: tt.tools},
+			})
+			for path, content := range tt.generated {
+				manifest.Record(path, []byte(content))
+			}
+			if err := manifest.Save(dir); err != nil {
+				t.Fatal(err)
+			}
+			for path, content := range tt.onDisk {
+				writeTestFile(t, dir, path, content)
+			}
+
+			// A dry run reports the same and changes nothing
+			dryRun := NewCleaner(dir, manifest)
+			dryRun.Match = tt.match
+			dryRun.DryRun = true
+			if err := dryRun.Clean(); err != nil {
+				t.Fatalf("Clean() dry run error = %v", err)
+			}
+			if !slices.Equal(dryRun.Removed(), tt.wantRemoved) {
+				t.Errorf("dry run Removed() = %v, want %v", dryRun.Removed(), tt.wantRemoved)
+			}
+			for path := range tt.onDisk {
+				if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(path))); err != nil {
+					t.Errorf("dry run removed %s", path)
+				}
+			}
+
+			cleaner := NewCleaner(dir, manifest)
+			cleaner.Match = tt.match
+			if err := cleaner.Clean(); err != nil {
+				t.Fatalf("Clean() error = %v", err)
+			}
+			for _, check := range []struct {
+				name      string
+				got, want []string
+			}{
+				{"Removed", cleaner.Removed(), tt.wantRemoved},
+				{"Kept", cleaner.Kept(), tt.wantKept},
+				{"Missing", cleaner.Missing(), tt.wantMissing},
+				{"Tools", cleaner.Tools(), tt.wantTools},
+			} {
+				if !slices.Equal(check.got, check.want) {
+					t.Errorf("%s() = %v, want %v", check.name, check.got, check.want)
+				}
+			}
+			if cleaner.Uninstalled() != tt.wantUninstalled {
+				t.Errorf("Uninstalled() = %v, want %v", cleaner.Uninstalled(), tt.wantUninstalled)
+			}
+
+			for _, path := range tt.wantLeft {
+				if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(path))); err != nil {
+					t.Errorf("%s was removed", path)
+				}
+			}
+			for _, path := range tt.wantGone {
+				if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(path))); !os.IsNotExist(err) {
+					t.Errorf("%s is left", path)
+				}
+			}
+			for _, path := range append(tt.wantRemoved, tt.wantMissing...) {
+				if _, recorded := manifest.Files[path]; recorded {
+					t.Errorf("%s is still recorded", path)
+				}
+			}
+			for _, tool := range tt.wantTools {
+				if slices.Contains(manifest.Config.DevOps.Tools, tool) {
+					t.Errorf("DevOps tool %s is still recorded", tool)
+				}
+			}
+		})
+	}
+}
+
+// writeTestFile writes a file of a test project, creating its directory
+func writeTestFile(t *testing.T, dir, path, content string) {
+	t.Helper()
+	file := filepath.Join(dir, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
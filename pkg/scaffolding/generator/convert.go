@@ -0,0 +1,1121 @@
+// pkg/scaffolding/generator/convert.go
+
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/NarmadaWeb/goback/pkg/config"
+	"github.com/NarmadaWeb/goback/pkg/project"
+)
+
+// Layers of a project whose package directory depends on the architecture.
+// layerPorts holds the repository interfaces.
+const (
+	layerConfig       = "config"
+	layerDatabase     = "database"
+	layerRoutes       = "routes"
+	layerHandlers     = "handlers"
+	layerMiddleware   = "middleware"
+	layerMigrate      = "migrate"
+	layerWorker       = "worker"
+	layerCLI          = "cli"
+	layerServices     = "services"
+	layerModels       = "models"
+	layerRepositories = "repositories"
+	layerPorts        = "ports"
+	layerUtils        = "utils"
+)
+
+// convertLayers lists the layers in the order their directories are mapped
+var convertLayers = []string{
+	layerConfig, layerDatabase, layerRoutes, layerHandlers, layerMiddleware, layerMigrate, layerWorker,
+	layerCLI, layerServices, layerModels, layerRepositories, layerPorts, layerUtils,
+}
+
+// architectureLayout holds the package directory of every layer for each architecture
+var architectureLayout = map[config.ArchitectureChoice]map[string]string{
+	config.ArchitectureSimple: {
+		layerConfig: "internal/config", layerDatabase: "internal/database", layerRoutes: "internal/routes",
+		layerHandlers: "internal/handlers", layerMiddleware: "internal/middleware", layerMigrate: "internal/migrate",
+		layerWorker: "internal/worker", layerCLI: "internal/cli", layerServices: "internal/services",
+		layerModels: "internal/models", layerRepositories: "internal/repositories", layerPorts: "internal/repositories",
+		layerUtils: "internal/utils",
+	},
+	config.ArchitectureDDD: {
+		layerConfig: "config", layerDatabase: "infrastructure/database", layerRoutes: "interfaces/routes",
+		layerHandlers: "interfaces/handlers", layerMiddleware: "interfaces/middleware", layerMigrate: "pkg/migrate",
+		layerWorker: "interfaces/worker", layerCLI: "interfaces/cli", layerServices: "domain/services",
+		layerModels: "domain/entities", layerRepositories: "infrastructure/repositories", layerPorts: "domain/repositories",
+		layerUtils: "domain/utils",
+	},
+	config.ArchitectureClean: {
+		layerConfig: "config", layerDatabase: "infrastructure/database", layerRoutes: "interfaces/routes",
+		layerHandlers: "interfaces/handlers", layerMiddleware: "interfaces/middleware", layerMigrate: "pkg/migrate",
+		layerWorker: "interfaces/worker", layerCLI: "interfaces/cli", layerServices: "domain/usecases",
+		layerModels: "domain/entities", layerRepositories: "infrastructure/repositories", layerPorts: "domain/usecases",
+		layerUtils: "domain/utils",
+	},
+	config.ArchitectureHexagonal: {
+		layerConfig: "config", layerDatabase: "adapters/secondary/database", layerRoutes: "adapters/primary/http",
+		layerHandlers: "adapters/primary/http", layerMiddleware: "adapters/primary/http", layerMigrate: "pkg/migrate",
+		layerWorker: "adapters/primary/worker", layerCLI: "adapters/primary/cli", layerServices: "applications/services",
+		layerModels: "domain", layerRepositories: "adapters/secondary/databases", layerPorts: "ports",
+		layerUtils: "domain/utils",
+	},
+}
+
+// FileMove is a file moved to the layout of another architecture
+type FileMove struct {
+	From string
+	To   string
+}
+
+// symbolKey names a package level declaration by the import path of its package
+type symbolKey struct {
+	pkg, name string
+}
+
+// Converter moves the packages of a project to the layout of another
+// architecture. Files are moved by layer, package clauses, imports and
+// qualified identifiers are rewritten on the syntax tree, so the wiring in
+// routes.Setup and main follows. When the target keeps repository interfaces
+// in the domain, exported interfaces of the repositories package and the
+// type aliases they use are moved there if they only depend on other
+// packages. Plan reports what cannot be
+// mapped; conflicts make it fail before anything is written.
+type Converter struct {
+	Dir    string
+	Config *config.ProjectConfig
+	To     config.ArchitectureChoice
+	// Manifest, when set, follows the moved files and records the new
+	// architecture; files unchanged since generation stay pristine
+	Manifest *project.Manifest
+
+	modulePath string
+	dirs       map[string]string          // old package directory -> new one
+	moved      map[symbolKey]string       // declaration moved to another package -> its import path
+	drops      map[string]map[string]bool // file -> interfaces and aliases moved out of it
+	shared     map[string]bool            // moved aliases the ports package already declares
+	moves      []FileMove
+	contents   map[string][]byte // new path -> content of rewritten or moved Go files
+	ports      map[string]string // new file of the ports package -> file its declarations come from
+	rewritten  []string
+	extracted  []string
+	notes      []string
+	planned    bool
+}
+
+// NewConverter creates a converter of the project in dir, described by cfg, to the architecture to
+func NewConverter(dir string, cfg *config.ProjectConfig, to config.ArchitectureChoice) *Converter {
+	return &Converter{Dir: dir, Config: cfg, To: to}
+}
+
+// Moves returns the files moved, by their old path
+func (c *Converter) Moves() []FileMove {
+	return c.moves
+}
+
+// Rewritten returns the files rewritten in place
+func (c *Converter) Rewritten() []string {
+	return c.rewritten
+}
+
+// Extracted returns the repository interfaces and aliases moved to the domain, as package.Name
+func (c *Converter) Extracted() []string {
+	return c.extracted
+}
+
+// Notes returns what could not be converted and was left as it is
+func (c *Converter) Notes() []string {
+	return c.notes
+}
+
+// Plan works out the moves and rewrites without writing anything. It fails
+// when the conversion is not supported or files would conflict.
+func (c *Converter) Plan() error {
+	from := c.Config.Architecture
+	if from == c.To {
+		return fmt.Errorf("the project already uses %s", from)
+	}
+	fromLayout, toLayout := architectureLayout[from], architectureLayout[c.To]
+	if fromLayout == nil || toLayout == nil {
+		return fmt.Errorf("cannot convert from %q to %q", from, c.To)
+	}
+
+	c.modulePath = c.Config.ModulePath
+	if mod, err := project.ReadGoMod(c.Dir); err == nil {
+		c.modulePath = mod.Module
+	}
+
+	// Map every package directory of the old layout to exactly one new one.
+	// Repository interfaces that share the package of their implementations
+	// are split off below instead.
+	split := fromLayout[layerPorts] == fromLayout[layerRepositories] && toLayout[layerPorts] != toLayout[layerRepositories]
+	c.dirs = map[string]string{}
+	for _, layer := range convertLayers {
+		if layer == layerPorts && split {
+			continue
+		}
+		src, dst := fromLayout[layer], toLayout[layer]
+		if mapped, ok := c.dirs[src]; ok && mapped != dst {
+			return fmt.Errorf("cannot convert from %s: %s holds the %s of the new layout in different packages (%s and %s)",
+				from, src, layer, mapped, dst)
+		}
+		c.dirs[src] = dst
+	}
+
+	files, err := c.projectFiles()
+	if err != nil {
+		return err
+	}
+	parsed := map[string]*ast.File{}
+	for _, file := range files {
+		if !strings.HasSuffix(file, ".go") {
+			continue
+		}
+		src, err := os.ReadFile(filepath.Join(c.Dir, filepath.FromSlash(file)))
+		if err != nil {
+			return err
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), file, src, parser.ParseComments)
+		if err != nil {
+			return fmt.Errorf("cannot convert a project that does not parse: %w", err)
+		}
+		parsed[file] = f
+	}
+
+	c.moved = map[symbolKey]string{}
+	c.drops = map[string]map[string]bool{}
+	if split {
+		c.planInterfaceSplit(parsed, fromLayout[layerRepositories], toLayout[layerPorts])
+	}
+
+	c.contents = map[string][]byte{}
+	c.ports = map[string]string{}
+	targets := map[string]string{} // new path -> old path
+	for _, file := range files {
+		newPath := c.mapFile(file)
+		if newPath != file {
+			c.moves = append(c.moves, FileMove{From: file, To: newPath})
+			if other, ok := targets[newPath]; ok {
+				return fmt.Errorf("%s and %s would both move to %s", other, file, newPath)
+			}
+			targets[newPath] = file
+		}
+		if parsed[file] == nil {
+			continue
+		}
+
+		src, err := os.ReadFile(filepath.Join(c.Dir, filepath.FromSlash(file)))
+		if err != nil {
+			return err
+		}
+		content, err := c.rewriteFile(file, newPath, src, c.drops[file])
+		if err != nil {
+			return err
+		}
+		if newPath != file || !bytes.Equal(content, src) {
+			c.contents[newPath] = content
+		}
+		if newPath == file && !bytes.Equal(content, src) {
+			c.rewritten = append(c.rewritten, file)
+		}
+
+		if c.extracts(file) {
+			portPath := path.Join(toLayout[layerPorts], path.Base(file))
+			content, err := c.extractInterfaces(file, portPath, src, parsed[file])
+			if err != nil {
+				return err
+			}
+			if other, ok := targets[portPath]; ok {
+				return fmt.Errorf("%s and the interfaces of %s would both be written to %s", other, file, portPath)
+			}
+			targets[portPath] = file
+			c.contents[portPath] = content
+			c.ports[portPath] = file
+		}
+	}
+
+	// Files of the new layout must not overwrite files that stay in place
+	moving := map[string]bool{}
+	for _, move := range c.moves {
+		moving[move.From] = true
+	}
+	for target, source := range targets {
+		if _, err := os.Stat(filepath.Join(c.Dir, filepath.FromSlash(target))); err == nil && !moving[target] {
+			return fmt.Errorf("%s already exists, move or remove it before converting %s", target, source)
+		}
+	}
+	if err := c.checkMergedPackages(parsed); err != nil {
+		return err
+	}
+
+	c.noteUnmapped(files, fromLayout)
+	sort.Slice(c.moves, func(i, j int) bool { return c.moves[i].From < c.moves[j].From })
+	sort.Strings(c.rewritten)
+	sort.Strings(c.extracted)
+	c.planned = true
+	return nil
+}
+
+// Convert plans the conversion when needed and writes it
+func (c *Converter) Convert() error {
+	if !c.planned {
+		if err := c.Plan(); err != nil {
+			return err
+		}
+	}
+
+	states := map[string]project.FileState{}
+	if c.Manifest != nil {
+		for path := range c.Manifest.Files {
+			states[path] = c.Manifest.State(c.Dir, path)
+		}
+	}
+
+	for _, move := range c.moves {
+		dest := filepath.Join(c.Dir, filepath.FromSlash(move.To))
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return err
+		}
+		if _, rewritten := c.contents[move.To]; rewritten {
+			continue
+		}
+		if err := os.Rename(filepath.Join(c.Dir, filepath.FromSlash(move.From)), dest); err != nil {
+			return fmt.Errorf("failed to move %s: %w", move.From, err)
+		}
+	}
+	for newPath, content := range c.contents {
+		dest := filepath.Join(c.Dir, filepath.FromSlash(newPath))
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(dest, content, 0644); err != nil {
+			return fmt.Errorf("failed to write file %s: %w", newPath, err)
+		}
+	}
+	for _, move := range c.moves {
+		if _, rewritten := c.contents[move.To]; !rewritten {
+			continue
+		}
+		if err := os.Remove(filepath.Join(c.Dir, filepath.FromSlash(move.From))); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", move.From, err)
+		}
+	}
+	c.removeEmptyDirs()
+
+	if c.Manifest != nil {
+		c.updateManifest(states)
+	}
+	return nil
+}
+
+// updateManifest follows the moves in the recorded hashes
+func (c *Converter) updateManifest(states map[string]project.FileState) {
+	m := c.Manifest
+	pristine := func(old string) bool { return states[old] == project.FilePristine }
+
+	for _, move := range c.moves {
+		wasPristine := pristine(move.From)
+		delete(m.Files, move.From)
+		if wasPristine {
+			if content, ok := c.contents[move.To]; ok {
+				m.Record(move.To, content)
+			} else if content, err := os.ReadFile(filepath.Join(c.Dir, filepath.FromSlash(move.To))); err == nil {
+				m.Record(move.To, content)
+			}
+		}
+	}
+	for _, file := range c.rewritten {
+		if pristine(file) {
+			m.Record(file, c.contents[file])
+		} else {
+			delete(m.Files, file)
+		}
+	}
+	for portPath, file := range c.ports {
+		if pristine(file) {
+			m.Record(portPath, c.contents[portPath])
+		}
+	}
+	m.Config.Architecture = c.To
+}
+
+// projectFiles lists the files of the project, skipping nested modules
+func (c *Converter) projectFiles() ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(c.Dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(c.Dir, p)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+		if d.IsDir() {
+			if relPath == "." {
+				return nil
+			}
+			for _, skipped := range renameSkippedDirs {
+				if d.Name() == skipped {
+					return filepath.SkipDir
+				}
+			}
+			if _, err := os.Stat(filepath.Join(p, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Type().IsRegular() {
+			files = append(files, relPath)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// mapDir returns the new directory of a slash separated directory; packages
+// below a layer move along with it
+func (c *Converter) mapDir(dir string) string {
+	best := ""
+	for src := range c.dirs {
+		if (dir == src || strings.HasPrefix(dir, src+"/")) && len(src) > len(best) {
+			best = src
+		}
+	}
+	if best == "" {
+		return dir
+	}
+	return c.dirs[best] + strings.TrimPrefix(dir, best)
+}
+
+// mapFile returns the new path of a project file
+func (c *Converter) mapFile(file string) string {
+	return path.Join(c.mapDir(path.Dir(file)), path.Base(file))
+}
+
+// mapImport returns the new import path of a package of the project
+func (c *Converter) mapImport(importPath string) string {
+	if importPath == c.modulePath || !strings.HasPrefix(importPath, c.modulePath+"/") {
+		return importPath
+	}
+	return c.modulePath + "/" + c.mapDir(strings.TrimPrefix(importPath, c.modulePath+"/"))
+}
+
+// planInterfaceSplit selects the exported interfaces of the repositories
+// package that can move to the ports package, along with the exported type
+// aliases they use. A declaration that refers to other declarations of its
+// own package stays, and the split is dropped when the ports package would
+// still import the repositories package, as the implementations then import
+// the ports package and the packages would form a cycle. An alias that the
+// ports package already declares identically, such as UserID, is shared
+// rather than moved.
+func (c *Converter) planInterfaceSplit(parsed map[string]*ast.File, reposDir, portsDir string) {
+	reposPath := c.modulePath + "/" + reposDir
+	portsPath := c.modulePath + "/" + portsDir
+
+	type candidate struct {
+		file  string
+		spec  *ast.TypeSpec
+		local []string
+	}
+	candidates := map[string]candidate{}
+	for file, f := range parsed {
+		if path.Dir(file) != reposDir || strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		for _, decl := range f.Decls {
+			if spec := movableType(decl); spec != nil && spec.Name.IsExported() {
+				candidates[spec.Name.Name] = candidate{file, spec, localIdents(spec.Type)}
+			}
+		}
+	}
+
+	// Drop the declarations that use declarations which stay
+	for changed := true; changed; {
+		changed = false
+		for name, cand := range candidates {
+			for _, local := range cand.local {
+				if _, ok := candidates[local]; !ok {
+					if _, isInterface := cand.spec.Type.(*ast.InterfaceType); isInterface {
+						c.notes = append(c.notes, fmt.Sprintf("%s stays in %s, it uses %s of the same package",
+							name, c.mapDir(reposDir), local))
+					}
+					delete(candidates, name)
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	// Aliases only move along with the interfaces that use them
+	moving := map[string]bool{}
+	var use func(name string)
+	use = func(name string) {
+		if moving[name] {
+			return
+		}
+		moving[name] = true
+		for _, local := range candidates[name].local {
+			use(local)
+		}
+	}
+	for name, cand := range candidates {
+		if _, ok := cand.spec.Type.(*ast.InterfaceType); ok {
+			use(name)
+		}
+	}
+	if len(moving) == 0 {
+		return
+	}
+
+	// Aliases the ports package already declares with the same target
+	existing := map[string]string{}
+	for file, f := range parsed {
+		if c.mapDir(path.Dir(file)) != portsDir || strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		for _, decl := range f.Decls {
+			if spec := movableType(decl); spec != nil && spec.Assign.IsValid() {
+				existing[spec.Name.Name] = aliasTarget(f, spec)
+			}
+		}
+	}
+
+	c.shared = map[string]bool{}
+	for name := range moving {
+		cand := candidates[name]
+		if target, ok := existing[name]; ok && cand.spec.Assign.IsValid() && target != "" && target == aliasTarget(parsed[cand.file], cand.spec) {
+			c.shared[name] = true
+		}
+		if c.drops[cand.file] == nil {
+			c.drops[cand.file] = map[string]bool{}
+		}
+		c.drops[cand.file][name] = true
+		c.moved[symbolKey{reposPath, name}] = portsPath
+	}
+
+	// The packages that end up in the ports directory must not need the implementations
+	for file, f := range parsed {
+		if c.mapDir(path.Dir(file)) != portsDir {
+			continue
+		}
+		for _, ref := range qualifiedRefs(f) {
+			if ref.pkg == reposPath && c.moved[ref] == "" {
+				c.notes = append(c.notes, fmt.Sprintf("The repository interfaces stay in %s: %s uses %s.%s, which would make %s and %s import each other",
+					c.mapDir(reposDir), file, path.Base(reposDir), ref.name, c.mapDir(reposDir), portsDir))
+				c.moved = map[symbolKey]string{}
+				c.drops = map[string]map[string]bool{}
+				c.shared = nil
+				return
+			}
+		}
+	}
+	for name := range moving {
+		if !c.shared[name] {
+			c.extracted = append(c.extracted, path.Base(portsDir)+"."+name)
+		}
+	}
+}
+
+// movableType returns the spec of a declaration of exactly one interface
+// type or type alias, the declarations that can move to the ports package
+func movableType(decl ast.Decl) *ast.TypeSpec {
+	gen, ok := decl.(*ast.GenDecl)
+	if !ok || gen.Tok != token.TYPE || len(gen.Specs) != 1 {
+		return nil
+	}
+	spec := gen.Specs[0].(*ast.TypeSpec)
+	if spec.TypeParams != nil {
+		return nil
+	}
+	if _, ok := spec.Type.(*ast.InterfaceType); !ok && !spec.Assign.IsValid() {
+		return nil
+	}
+	return spec
+}
+
+// aliasTarget identifies the type an alias of an imported or predeclared
+// type denotes, or returns "" for other aliases
+func aliasTarget(f *ast.File, spec *ast.TypeSpec) string {
+	switch t := spec.Type.(type) {
+	case *ast.Ident:
+		if isPredeclared(t.Name) {
+			return t.Name
+		}
+	case *ast.SelectorExpr:
+		if x, ok := t.X.(*ast.Ident); ok {
+			if importPath, ok := importsByName(f)[x.Name]; ok {
+				return importPath + "." + t.Sel.Name
+			}
+		}
+	}
+	return ""
+}
+
+// localIdents returns the identifiers of a type expression that are neither
+// qualified nor predeclared, i.e. declarations of the same package
+func localIdents(expr ast.Expr) []string {
+	names := map[*ast.Ident]bool{}
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if field, ok := n.(*ast.Field); ok {
+			// Method and parameter names
+			for _, name := range field.Names {
+				names[name] = true
+			}
+		}
+		return true
+	})
+
+	var local []string
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			return false
+		case *ast.Ident:
+			if !names[n] && !isPredeclared(n.Name) {
+				local = append(local, n.Name)
+			}
+		}
+		return true
+	})
+	sort.Strings(local)
+	return compactStrings(local)
+}
+
+// isPredeclared reports whether name is a predeclared type of Go
+func isPredeclared(name string) bool {
+	switch name {
+	case "any", "bool", "byte", "comparable", "complex64", "complex128", "error", "float32", "float64",
+		"int", "int8", "int16", "int32", "int64", "rune", "string",
+		"uint", "uint8", "uint16", "uint32", "uint64", "uintptr":
+		return true
+	}
+	return false
+}
+
+func compactStrings(s []string) []string {
+	var out []string
+	for i, v := range s {
+		if i == 0 || v != s[i-1] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// qualifiedRefs returns the package level declarations of imported packages a file uses
+func qualifiedRefs(f *ast.File) []symbolKey {
+	imports := importsByName(f)
+	var refs []symbolKey
+	ast.Inspect(f, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if x, ok := sel.X.(*ast.Ident); ok && x.Obj == nil {
+			if importPath, ok := imports[x.Name]; ok {
+				refs = append(refs, symbolKey{importPath, sel.Sel.Name})
+			}
+		}
+		return true
+	})
+	return refs
+}
+
+// importsByName maps the names under which a file's imports are used to their paths
+func importsByName(f *ast.File) map[string]string {
+	imports := map[string]string{}
+	for _, spec := range f.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		imports[importName(spec, importPath)] = importPath
+	}
+	return imports
+}
+
+// importName returns the name under which an import spec is used
+func importName(spec *ast.ImportSpec, importPath string) string {
+	if spec.Name != nil {
+		return spec.Name.Name
+	}
+	return defaultImportName(importPath)
+}
+
+// rewriteFile rewrites a Go file for its new path: the package clause, the
+// imports of moved packages and the identifiers qualified with them.
+// The interfaces and aliases in drop are removed, their uses in the file are
+// qualified with the ports package.
+func (c *Converter) rewriteFile(file, newPath string, src []byte, drop map[string]bool) ([]byte, error) {
+	patch, err := parseSourcePatch(file, src)
+	if err != nil {
+		return nil, err
+	}
+	f := patch.file
+	newPkgPath := c.modulePath + "/" + path.Dir(newPath)
+
+	// Package clause
+	if f.Name.Name != "main" && path.Dir(newPath) != path.Dir(file) {
+		name := defaultImportName(newPkgPath)
+		if strings.HasSuffix(f.Name.Name, "_test") {
+			name += "_test"
+		}
+		if name != f.Name.Name {
+			patch.replace(f.Name.Pos(), f.Name.End(), name)
+		}
+	}
+
+	// Declarations moving to the ports package
+	var removed [][2]token.Pos
+	for _, decl := range f.Decls {
+		if spec := movableType(decl); spec != nil && drop[spec.Name.Name] {
+			start := decl.Pos()
+			if doc := decl.(*ast.GenDecl).Doc; doc != nil {
+				start = doc.Pos()
+			}
+			removed = append(removed, [2]token.Pos{start, decl.End()})
+			patch.replace(start, decl.End(), "")
+		}
+	}
+	inRemoved := func(pos token.Pos) bool {
+		for _, r := range removed {
+			if pos >= r[0] && pos < r[1] {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Targets of the qualified identifiers
+	type qualified struct {
+		sel    *ast.SelectorExpr
+		target string
+	}
+	imports := importsByName(f)
+	var refs []qualified
+	needed := map[string]bool{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok || inRemoved(sel.Pos()) {
+			return true
+		}
+		x, ok := sel.X.(*ast.Ident)
+		if !ok || x.Obj != nil {
+			return true
+		}
+		importPath, ok := imports[x.Name]
+		if !ok {
+			return true
+		}
+		target := c.moved[symbolKey{importPath, sel.Sel.Name}]
+		if target == "" {
+			target = c.mapImport(importPath)
+		}
+		refs = append(refs, qualified{sel, target})
+		if target != newPkgPath {
+			needed[target] = true
+		}
+		return true
+	})
+
+	// Uses of the declarations that moved out of this package
+	oldPkgPath := c.modulePath + "/" + path.Dir(file)
+	var unqualified []*ast.Ident
+	var portsPath string
+	for key, target := range c.moved {
+		if key.pkg == oldPkgPath && target != newPkgPath {
+			portsPath = target
+		}
+	}
+	if portsPath != "" {
+		for _, id := range typeIdents(f) {
+			if c.moved[symbolKey{oldPkgPath, id.Name}] != "" && !inRemoved(id.Pos()) {
+				unqualified = append(unqualified, id)
+				needed[portsPath] = true
+			}
+		}
+	}
+
+	// Names of the imports in the rewritten file
+	names := map[string]string{}
+	used := map[string]bool{}
+	var owned []*ast.ImportSpec
+	for _, spec := range f.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		if c.mapImport(importPath) == importPath {
+			name := importName(spec, importPath)
+			used[name] = true
+			if _, ok := names[importPath]; !ok {
+				names[importPath] = name
+			}
+		}
+	}
+	assign := func(target, alias string) string {
+		if name, ok := names[target]; ok {
+			return name
+		}
+		name := alias
+		if name == "" || used[name] {
+			name = defaultImportName(target)
+		}
+		if used[name] {
+			parent := path.Base(path.Dir(target))
+			name = strings.NewReplacer("-", "_", ".", "_").Replace(parent) + "_" + defaultImportName(target)
+		}
+		names[target], used[name] = name, true
+		return name
+	}
+	for _, spec := range f.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		target := c.mapImport(importPath)
+		if target == importPath || !needed[target] || names[target] != "" {
+			continue
+		}
+		alias := ""
+		if spec.Name != nil {
+			alias = spec.Name.Name
+		}
+		assign(target, alias)
+		owned = append(owned, spec)
+	}
+	var added []string
+	for target := range needed {
+		if _, ok := names[target]; !ok {
+			assign(target, "")
+			added = append(added, target)
+		}
+	}
+	sort.Strings(added)
+
+	// Qualifiers
+	for _, ref := range refs {
+		x := ref.sel.X.(*ast.Ident)
+		switch {
+		case ref.target == newPkgPath:
+			patch.replace(x.Pos(), ref.sel.Sel.Pos(), "")
+		case names[ref.target] != "" && names[ref.target] != x.Name:
+			patch.replace(x.Pos(), x.End(), names[ref.target])
+		}
+	}
+	for _, id := range unqualified {
+		patch.insert(id.Pos(), names[portsPath]+".")
+	}
+
+	// Import specs
+	referenced := map[string]bool{}
+	for _, ref := range refs {
+		referenced[imports[ref.sel.X.(*ast.Ident).Name]] = true
+	}
+	importSpec := func(target string) string {
+		if names[target] == defaultImportName(target) {
+			return strconv.Quote(target)
+		}
+		return names[target] + " " + strconv.Quote(target)
+	}
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		kept := 0
+		var edits []func()
+		for _, s := range gen.Specs {
+			spec := s.(*ast.ImportSpec)
+			importPath, _ := strconv.Unquote(spec.Path.Value)
+			target := c.mapImport(importPath)
+			isOwner := false
+			for _, o := range owned {
+				isOwner = isOwner || o == spec
+			}
+			blank := spec.Name != nil && (spec.Name.Name == "_" || spec.Name.Name == ".")
+			switch {
+			case len(removed) > 0 && !blank && !referenced[importPath]:
+				// Only used by the declarations moved out
+				edits = append(edits, func() { patch.replace(spec.Pos(), spec.End(), "") })
+			case target == importPath && !c.hasMovedSymbols(importPath):
+				kept++
+			case blank:
+				kept++
+				edits = append(edits, func() { patch.replace(spec.Path.Pos(), spec.Path.End(), strconv.Quote(target)) })
+			case isOwner:
+				kept++
+				edits = append(edits, func() { patch.replace(spec.Pos(), spec.End(), importSpec(target)) })
+			case target == importPath && needed[target]:
+				kept++
+			default:
+				edits = append(edits, func() { patch.replace(spec.Pos(), spec.End(), "") })
+			}
+		}
+		if kept == 0 && !gen.Lparen.IsValid() && len(added) == 0 {
+			patch.replace(gen.Pos(), gen.End(), "")
+			continue
+		}
+		for _, edit := range edits {
+			edit()
+		}
+	}
+	for _, target := range added {
+		patch.addImport(importSpec(target))
+	}
+
+	if len(patch.edits) == 0 {
+		return src, nil
+	}
+	return patch.apply()
+}
+
+// extracts reports whether declarations of file are written to the ports package
+func (c *Converter) extracts(file string) bool {
+	for name := range c.drops[file] {
+		if !c.shared[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// hasMovedSymbols reports whether declarations of the package moved elsewhere
+func (c *Converter) hasMovedSymbols(importPath string) bool {
+	for key := range c.moved {
+		if key.pkg == importPath {
+			return true
+		}
+	}
+	return false
+}
+
+// typeIdents returns the unqualified identifiers of a file that are used,
+// leaving out declared names, field and method names and selectors
+func typeIdents(f *ast.File) []*ast.Ident {
+	declared := map[*ast.Ident]bool{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			declared[n.Sel] = true
+		case *ast.Field:
+			for _, name := range n.Names {
+				declared[name] = true
+			}
+		case *ast.TypeSpec:
+			declared[n.Name] = true
+		case *ast.FuncDecl:
+			declared[n.Name] = true
+		case *ast.ValueSpec:
+			for _, name := range n.Names {
+				declared[name] = true
+			}
+		case *ast.KeyValueExpr:
+			if id, ok := n.Key.(*ast.Ident); ok {
+				declared[id] = true
+			}
+		}
+		return true
+	})
+
+	var idents []*ast.Ident
+	ast.Inspect(f, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && !declared[id] && id != f.Name {
+			idents = append(idents, id)
+		}
+		return true
+	})
+	return idents
+}
+
+// extractInterfaces writes the interfaces moved out of file into a new file
+// of the ports package, with the file's leading comments and the imports they need
+func (c *Converter) extractInterfaces(file, portPath string, src []byte, f *ast.File) ([]byte, error) {
+	fset := token.NewFileSet()
+	parsed, err := parser.ParseFile(fset, file, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	offset := func(pos token.Pos) int { return fset.Position(pos).Offset }
+
+	var b bytes.Buffer
+	b.Write(src[:offset(parsed.Package)])
+	fmt.Fprintf(&b, "package %s\n\n", f.Name.Name)
+	for _, decl := range parsed.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			b.Write(src[offset(gen.Pos()):offset(gen.End())])
+			b.WriteString("\n\n")
+		}
+	}
+	for _, decl := range parsed.Decls {
+		spec := movableType(decl)
+		if spec == nil || !c.drops[file][spec.Name.Name] || c.shared[spec.Name.Name] {
+			continue
+		}
+		start := decl.Pos()
+		if doc := decl.(*ast.GenDecl).Doc; doc != nil {
+			start = doc.Pos()
+		}
+		b.Write(src[offset(start):offset(decl.End())])
+		b.WriteString("\n\n")
+	}
+
+	// The imports the interfaces do not use are dropped by the rewrite
+	content, err := c.rewriteFile(file, portPath, b.Bytes(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return removeUnusedImports(portPath, content)
+}
+
+// removeUnusedImports removes the imports a file does not refer to
+func removeUnusedImports(file string, src []byte) ([]byte, error) {
+	patch, err := parseSourcePatch(file, src)
+	if err != nil {
+		return nil, err
+	}
+	refs := map[string]bool{}
+	for _, ref := range qualifiedRefs(patch.file) {
+		refs[ref.pkg] = true
+	}
+	for _, spec := range patch.file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		if spec.Name != nil && (spec.Name.Name == "_" || spec.Name.Name == ".") {
+			continue
+		}
+		if !refs[importPath] {
+			patch.replace(spec.Pos(), spec.End(), "")
+		}
+	}
+	return patch.apply()
+}
+
+// checkMergedPackages fails when packages merged into one directory declare the same names
+func (c *Converter) checkMergedPackages(parsed map[string]*ast.File) error {
+	type origin struct{ dir, file string }
+	declared := map[string]map[string]origin{} // new dir -> name -> origin
+	files := make([]string, 0, len(parsed))
+	for file := range parsed {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		f := parsed[file]
+		if strings.HasSuffix(file, "_test.go") || f.Name.Name == "main" {
+			continue
+		}
+		newDir := c.mapDir(path.Dir(file))
+		if declared[newDir] == nil {
+			declared[newDir] = map[string]origin{}
+		}
+		for _, name := range topLevelNames(f, c.drops[file]) {
+			if name == "_" || name == "init" {
+				continue
+			}
+			if prev, ok := declared[newDir][name]; ok && prev.dir != path.Dir(file) {
+				return fmt.Errorf("%s and %s both declare %s, which would be in the same package %s",
+					prev.file, file, name, newDir)
+			}
+			declared[newDir][name] = origin{path.Dir(file), file}
+		}
+	}
+
+	// Interfaces and aliases moved to the ports package
+	for key, target := range c.moved {
+		portsDir := strings.TrimPrefix(target, c.modulePath+"/")
+		if prev, ok := declared[portsDir][key.name]; ok && !c.shared[key.name] {
+			return fmt.Errorf("%s declares %s, which would conflict with the repository interfaces moved to %s",
+				prev.file, key.name, portsDir)
+		}
+	}
+	return nil
+}
+
+// topLevelNames returns the package level names a file declares, except those in skip
+func topLevelNames(f *ast.File, skip map[string]bool) []string {
+	var names []string
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil {
+				names = append(names, d.Name.Name)
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					if !skip[s.Name.Name] {
+						names = append(names, s.Name.Name)
+					}
+				case *ast.ValueSpec:
+					for _, name := range s.Names {
+						names = append(names, name.Name)
+					}
+				}
+			}
+		}
+	}
+	return names
+}
+
+// noteUnmapped reports the packages in the directories of the old layout
+// that no layer covers; they are left in place
+func (c *Converter) noteUnmapped(files []string, fromLayout map[string]string) {
+	// Directories that hold layers; pkg and the root hold shared code of their own
+	roots := map[string]bool{}
+	for _, dir := range fromLayout {
+		if root, _, nested := strings.Cut(dir, "/"); nested && root != "pkg" {
+			roots[root] = true
+		}
+	}
+	seen := map[string]bool{}
+	for _, file := range files {
+		dir := path.Dir(file)
+		root, _, _ := strings.Cut(dir, "/")
+		if !strings.HasSuffix(file, ".go") || seen[dir] || !roots[root] {
+			continue
+		}
+		seen[dir] = true
+		covered := false
+		for _, layerDir := range fromLayout {
+			covered = covered || dir == layerDir || strings.HasPrefix(dir, layerDir+"/")
+		}
+		if !covered {
+			c.notes = append(c.notes, fmt.Sprintf("%s is not a layer of %s and was left in place", dir, c.Config.Architecture))
+		}
+	}
+	sort.Strings(c.notes)
+}
+
+// removeEmptyDirs removes the directories the moves left empty
+func (c *Converter) removeEmptyDirs() {
+	dirs := map[string]bool{}
+	for _, move := range c.moves {
+		for dir := path.Dir(move.From); dir != "."; dir = path.Dir(dir) {
+			dirs[dir] = true
+		}
+	}
+	sorted := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		sorted = append(sorted, dir)
+	}
+	// Deepest first, so that parents are empty when they are reached
+	sort.Slice(sorted, func(i, j int) bool { return strings.Count(sorted[i], "/") > strings.Count(sorted[j], "/") })
+	for _, dir := range sorted {
+		full := filepath.Join(c.Dir, filepath.FromSlash(dir))
+		if entries, err := os.ReadDir(full); err == nil && len(entries) == 0 {
+			_ = os.Remove(full)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+// pkg/scaffolding/generator/convert_test.go
+
+package generator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/NarmadaWeb/goback/pkg/config"
+	"github.com/NarmadaWeb/goback/pkg/project"
+)
+
+// TestConvertBuilds generates a simple chi project, converts it to every other
+// architecture and builds the result. It needs the dependencies of the
+// generated project, from the module cache or the network.
+func TestConvertBuilds(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds generated projects")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not found")
+	}
+
+	for _, to := range []config.ArchitectureChoice{config.ArchitectureClean, config.ArchitectureDDD, config.ArchitectureHexagonal} {
+		t.Run(string(to), func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "shop")
+			cfg := &config.ProjectConfig{
+				ProjectName:  "shop",
+				ModulePath:   "example.com/shop",
+				OutputDir:    dir,
+				Kind:         config.KindAPI,
+				Framework:    config.FrameworkChi,
+				Database:     config.DatabasepostgresQL,
+				Tool:         config.ToolGorm,
+				Architecture: config.ArchitectureSimple,
+			}
+			if err := NewTemplateGenerator(cfg).Generate(); err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			if out, err := goCommand(dir, "mod", "tidy"); err != nil {
+				t.Skipf("dependencies of the generated project unavailable: %v\n%s", err, out)
+			}
+
+			manifest, err := project.LoadManifest(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			converter := NewConverter(dir, &manifest.Config, to)
+			converter.Manifest = manifest
+			if err := converter.Convert(); err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			if len(converter.Moves()) == 0 {
+				t.Error("Convert() moved no file")
+			}
+			if manifest.Config.Architecture != to {
+				t.Errorf("recorded architecture = %s, want %s", manifest.Config.Architecture, to)
+			}
+			for path := range manifest.Files {
+				if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(path))); err != nil {
+					t.Errorf("recorded file %s is missing: %v", path, err)
+				}
+			}
+			if _, err := os.Stat(filepath.Join(dir, "internal", "handlers")); !os.IsNotExist(err) {
+				t.Errorf("internal/handlers is left after the conversion")
+			}
+
+			if out, err := goCommand(dir, "build", "./..."); err != nil {
+				t.Errorf("go build of the converted project failed: %v\n%s", err, out)
+			}
+		})
+	}
+}
+
+// TestConvertRefusesConflicts checks that nothing is written when a moved file would replace another
+func TestConvertRefusesConflicts(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "shop")
+	cfg := &config.ProjectConfig{
+		ProjectName:  "shop",
+		ModulePath:   "example.com/shop",
+		OutputDir:    dir,
+		Kind:         config.KindAPI,
+		Framework:    config.FrameworkChi,
+		Database:     config.DatabasepostgresQL,
+		Tool:         config.ToolGorm,
+		Architecture: config.ArchitectureSimple,
+	}
+	if err := NewTemplateGenerator(cfg).Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	converter := NewConverter(dir, cfg, config.ArchitectureDDD)
+	if err := converter.Plan(); err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	conflict := filepath.Join(dir, filepath.FromSlash(converter.Moves()[0].To))
+	if err := os.MkdirAll(filepath.Dir(conflict), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(conflict, []byte("package keep\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewConverter(dir, cfg, config.ArchitectureDDD).Convert(); err == nil {
+		t.Fatal("Convert() over an existing file succeeded")
+	}
+	if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(converter.Moves()[0].From))); err != nil {
+		t.Errorf("%s was moved despite the conflict", converter.Moves()[0].From)
+	}
+}
+
+// goCommand runs the go command in dir and returns its combined output
+func goCommand(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	// The generated project is a module of its own, not part of any workspace
+	cmd.Env = append(os.Environ(), "GOWORK=off")
+	return cmd.CombinedOutput()
+}
@@ -0,0 +1,93 @@
+// pkg/scaffolding/generator/features.go
+
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Feature is an optional part of a project that can be added after generation
+type Feature struct {
+	Name        string
+	Description string
+	files       []string
+	generate    func(tg *TemplateGenerator) error
+}
+
+// features lists the features that can be added to an existing project
+var features = []Feature{
+	{Name: "docker", Description: "Dockerfile and docker-compose.yml", files: []string{"Dockerfile", "docker-compose.yml"}},
+	{Name: "makefile", Description: "Makefile with build, test and deployment targets", files: []string{"Makefile"}},
+	{Name: "env", Description: "Environment files .env and .env.example", files: []string{".env", ".env.example"}},
+	{Name: "license", Description: "LICENSE file for the configured license", generate: (*TemplateGenerator).generateLicenseFeature},
+}
+
+// GetFeatures returns the features that can be added to an existing project
+func GetFeatures() []Feature {
+	return features
+}
+
+// GetFeatureNames returns the names of the features that can be added
+func GetFeatureNames() []string {
+	names := make([]string, 0, len(features))
+	for _, feature := range features {
+		names = append(names, feature.Name)
+	}
+	return names
+}
+
+// GenerateFeature generates the files of a single feature into the output directory
+func (tg *TemplateGenerator) GenerateFeature(name string) error {
+	for _, feature := range features {
+		if feature.Name != strings.ToLower(name) {
+			continue
+		}
+		if feature.generate != nil {
+			return feature.generate(tg)
+		}
+		for _, dest := range feature.files {
+			if tg.providedByPlatform(dest) {
+				continue
+			}
+			if err := tg.generateFileFromTemplate(dest, baseTemplates[dest]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown feature %q, valid features: %s", name, strings.Join(GetFeatureNames(), ", "))
+}
+
+// generateLicenseFeature generates the LICENSE file, which requires a configured license
+func (tg *TemplateGenerator) generateLicenseFeature() error {
+	if tg.Config.License == "" {
+		return fmt.Errorf("no license configured, use --license to choose one")
+	}
+	return tg.generateLicense()
+}
+
+// GenerateDevOps generates the files of the given DevOps tools into the output
+// directory and adds the tools to the DevOps configuration.
+func (tg *TemplateGenerator) GenerateDevOps(tools []string) error {
+	merged, err := tg.Config.DevOps.WithTools(tools)
+	if err != nil {
+		return err
+	}
+	for i, tool := range tools {
+		tools[i] = strings.ToLower(tool)
+	}
+
+	// Only the files of the given tools are generated
+	devops := &tg.Config.DevOps
+	configured, enabled := devops.Tools, devops.Enabled
+	devops.Enabled = true
+	devops.Tools = tools
+	if err := tg.generateDevOpsFiles(); err != nil {
+		devops.Tools, devops.Enabled = configured, enabled
+		return err
+	}
+
+	tg.Config.DevOps = merged
+	return nil
+}
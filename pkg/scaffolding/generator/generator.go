@@ -12,10 +12,13 @@ import (
 	"path/filepath"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/NarmadaWeb/goback/pkg/config"
+	"github.com/NarmadaWeb/goback/pkg/project"
 	"github.com/NarmadaWeb/goback/pkg/scaffolding"
 	"github.com/iancoleman/strcase"
+	"golang.org/x/mod/modfile"
 	"helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/chartutil"
 	"helm.sh/helm/v3/pkg/engine"
@@ -28,22 +31,44 @@ const (
 	pathRoutes     = "interfaces/routes/routes.go"
 	pathHandlers   = "interfaces/handlers/handlers.go"
 	pathMiddleware = "interfaces/middleware/middleware.go"
+	pathWorker     = "interfaces/worker/worker.go"
+	pathCLI        = "interfaces/cli/root.go"
 	templatesDir   = "templates"
 	connectionTmpl = "connection.go.tmpl"
 	frameworksDir  = "frameworks"
+	kindsDir       = "kinds"
 	devopsDir      = "devops"
 	helmDir        = "helm"
 	ansibleDir     = "ansible"
+	licensesDir    = "licenses"
 )
 
+// baseTemplates maps the base project files to their templates
+var baseTemplates = map[string]string{
+	"go.mod":             "base/go.mod.tmpl",
+	".gitignore":         "base/gitignore.tmpl",
+	"README.md":          "base/README.md.tmpl",
+	"Makefile":           "base/Makefile.tmpl",
+	".env":               "base/.env.tmpl",
+	".env.example":       "base/env.example.tmpl",
+	"Dockerfile":         "base/Dockerfile.tmpl",
+	"docker-compose.yml": "base/docker-compose.yml.tmpl",
+}
+
 // TemplateGenerator handles project generation from templates
 type TemplateGenerator struct {
-	Config           *config.ProjectConfig
-	OutputDir        string
+	Config    *config.ProjectConfig
+	OutputDir string
+	// Protect reports whether an existing file must be left untouched. It is
+	// given the slash separated path relative to the output directory.
+	Protect          func(path string) bool
 	progressCallback func(step int, message string)
 	errorCallback    func(step int, err error)
 	currentStep      int
 	totalSteps       int
+	files            map[string]string
+	skipped          []string
+	rendered         map[string][]byte
 }
 
 // NewTemplateGenerator creates a new template generator
@@ -51,10 +76,21 @@ func NewTemplateGenerator(cfg *config.ProjectConfig) *TemplateGenerator {
 	return &TemplateGenerator{
 		Config:     cfg,
 		OutputDir:  cfg.OutputDir,
-		totalSteps: 7,
+		totalSteps: 12,
+		files:      map[string]string{},
 	}
 }
 
+// Files returns the hashes of the files generated so far, keyed by slash separated path
+func (tg *TemplateGenerator) Files() map[string]string {
+	return tg.files
+}
+
+// Skipped returns the files that were left untouched because they are protected
+func (tg *TemplateGenerator) Skipped() []string {
+	return tg.skipped
+}
+
 // SetProgressCallback sets the progress callback
 func (tg *TemplateGenerator) SetProgressCallback(callback func(step int, message string)) {
 	tg.progressCallback = callback
@@ -65,19 +101,37 @@ func (tg *TemplateGenerator) SetErrorCallback(callback func(step int, err error)
 	tg.errorCallback = callback
 }
 
-// Generate generates the project structure and files
-func (tg *TemplateGenerator) Generate() error {
-	steps := []struct {
-		name    string
-		handler func() error
-	}{
+// generationStep is a named step of project generation
+type generationStep struct {
+	name    string
+	handler func() error
+}
+
+// generationSteps returns the steps that render the project files
+func (tg *TemplateGenerator) generationSteps() []generationStep {
+	return []generationStep{
 		{"Validating configuration", tg.validateConfiguration},
 		{"Generating base files", tg.generateBaseFiles},
 		{"Generating framework files", tg.generateFrameworkFiles},
+		{"Generating worker and CLI files", tg.generateKindFiles},
 		{"Generating database config", tg.generateDatabaseConfig},
 		{"Generating Tool files", tg.generateToolFiles},
 		{"Generating architecture files", tg.generateArchitectureFiles},
+		{"Generating sample resource", tg.generateSample},
 		{"Generating DevOps files", tg.generateDevOpsFiles},
+		{"Generating license", tg.generateLicense},
+		{"Writing getting started guide", tg.generateGettingStarted},
+	}
+}
+
+// Generate generates the project structure and files
+func (tg *TemplateGenerator) Generate() error {
+	if tg.Config.CreatedAt.IsZero() {
+		tg.Config.CreatedAt = time.Now()
+	}
+	steps := append(tg.generationSteps(), generationStep{"Recording project metadata", tg.writeManifest})
+	if tg.Config.RepoMode == config.RepoWorkspace {
+		steps = append(steps, generationStep{"Adding the module to go.work", tg.AddToWorkspace})
 	}
 
 	for i, step := range steps {
@@ -94,41 +148,80 @@ func (tg *TemplateGenerator) Generate() error {
 	return nil
 }
 
+// Render renders the project files in memory without writing to the output
+// directory. The result is keyed by slash separated path.
+func (tg *TemplateGenerator) Render() (map[string][]byte, error) {
+	tg.rendered = map[string][]byte{}
+	defer func() { tg.rendered = nil }()
+
+	for i, step := range tg.generationSteps() {
+		if err := step.handler(); err != nil {
+			return nil, fmt.Errorf("step %d (%s) failed: %w", i+1, step.name, err)
+		}
+	}
+	return tg.rendered, nil
+}
+
 // generateFileFromTemplate is the main helper function for processing templates.
 // It reads a template file, creates the destination directory if it doesn't exist,
 // executes the template with the config data, and writes the result.
 func (tg *TemplateGenerator) generateFileFromTemplate(destPath, templatePath string, delims ...string) error {
+	// Leave out the built-in User sample when another sample is chosen
+	if !tg.Config.HasUserSample() && isUserSampleTemplate(filepath.ToSlash(templatePath)) {
+		return nil
+	}
+	// Leave out the HTTP handlers of projects without an API
+	if !tg.Config.Kind.HasAPI() && isHTTPTemplate(filepath.ToSlash(templatePath)) {
+		return nil
+	}
+
 	// Remove .tmpl extension from destination path
 	destPath = strings.TrimSuffix(destPath, ".tmpl")
 
-	fullDestPath := filepath.Join(tg.OutputDir, destPath)
+	content, err := tg.renderTemplate(templatePath, delims...)
+	if err != nil {
+		return err
+	}
+	return tg.writeFile(destPath, content)
+}
+
+// renderTemplate executes an embedded template with the project configuration
+func (tg *TemplateGenerator) renderTemplate(templatePath string, delims ...string) ([]byte, error) {
 	// All template paths are now relative to the embedded `templates` directory
 	fullTemplatePath := filepath.ToSlash(filepath.Join(templatesDir, templatePath))
 
 	// Read template content from embedded FS
 	templateContent, err := scaffolding.Templates.ReadFile(fullTemplatePath)
 	if err != nil {
-		return fmt.Errorf("failed to read embedded template %s: %w", fullTemplatePath, err)
+		return nil, fmt.Errorf("failed to read embedded template %s: %w", fullTemplatePath, err)
 	}
 
-	// Create destination directory if it doesn't exist
-	if err2 := os.MkdirAll(filepath.Dir(fullDestPath), 0755); err2 != nil {
-		return fmt.Errorf("failed to create directory for %s: %w", fullDestPath, err2)
+	// Parse and execute template
+	tmpl := template.New(filepath.Base(templatePath)).Funcs(templateFuncs())
+	if len(delims) == 2 {
+		tmpl = tmpl.Delims(delims[0], delims[1])
 	}
-
-	// Create destination file
-	outputFile, err := os.Create(fullDestPath)
+	parsedTmpl, err := tmpl.Parse(string(templateContent))
 	if err != nil {
-		return fmt.Errorf("failed to create destination file %s: %w", fullDestPath, err)
+		return nil, fmt.Errorf("failed to parse template %s: %w", templatePath, err)
+	}
+
+	// Use tg.Config directly so the template can access .Architecture.String(), etc.
+	var content bytes.Buffer
+	if err := parsedTmpl.Execute(&content, tg.Config); err != nil {
+		return nil, fmt.Errorf("failed to execute template %s: %w", templatePath, err)
 	}
-	defer outputFile.Close()
+	return content.Bytes(), nil
+}
 
-	// Custom template functions
-	funcMap := template.FuncMap{
+// templateFuncs returns the custom functions available to all templates
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
 		"title":      strings.ToTitle,
 		"toTitle":    strings.ToTitle,
 		"snakeCase":  strcase.ToSnake,
 		"kebabCase":  strcase.ToKebab,
+		"titleCase":  titleCase,
 		"upper":      strings.ToUpper,
 		"lower":      strings.ToLower,
 		"replaceAll": strings.ReplaceAll,
@@ -140,25 +233,124 @@ func (tg *TemplateGenerator) generateFileFromTemplate(destPath, templatePath str
 			return val
 		},
 	}
+}
 
-	// Parse and execute template
-	tmpl := template.New(filepath.Base(templatePath)).Funcs(funcMap)
-	if len(delims) == 2 {
-		tmpl = tmpl.Delims(delims[0], delims[1])
+// writeFile writes generated content to a path relative to the output directory.
+// Every generated file goes through here so per-file post-processing lives in one place.
+func (tg *TemplateGenerator) writeFile(destPath string, content []byte) error {
+	fullDestPath := filepath.Join(tg.OutputDir, destPath)
+	relPath := filepath.ToSlash(filepath.Clean(destPath))
+
+	if tg.Protect != nil && tg.Protect(relPath) {
+		tg.skipped = append(tg.skipped, relPath)
+		return nil
 	}
-	parsedTmpl, err := tmpl.Parse(string(templateContent))
+
+	if strings.HasSuffix(destPath, ".go") {
+		if err := tg.checkLanguageFeatures(relPath, content); err != nil {
+			return err
+		}
+		if tg.Config.SPDXHeader {
+			content = append([]byte(tg.spdxHeader()), content...)
+		}
+	}
+
+	tg.files[relPath] = project.HashContent(content)
+	if tg.rendered != nil {
+		tg.rendered[relPath] = content
+		return nil
+	}
+
+	// Create destination directory if it doesn't exist
+	if err := os.MkdirAll(filepath.Dir(fullDestPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", fullDestPath, err)
+	}
+
+	if err := os.WriteFile(fullDestPath, content, 0644); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", fullDestPath, err)
+	}
+	return nil
+}
+
+// writeManifest records the configuration and the generated files in the project
+func (tg *TemplateGenerator) writeManifest() error {
+	manifest := project.NewManifest(tg.Config)
+	manifest.Files = tg.files
+	if err := manifest.Save(tg.OutputDir); err != nil {
+		return fmt.Errorf("failed to write %s: %w", project.ManifestPath, err)
+	}
+	return nil
+}
+
+// AddToWorkspace adds the project to the go.work of the enclosing repository
+func (tg *TemplateGenerator) AddToWorkspace() error {
+	repo, err := project.DetectRepo(tg.OutputDir)
 	if err != nil {
-		return fmt.Errorf("failed to parse template %s: %w", templatePath, err)
+		return err
 	}
+	_, err = repo.UseModule(tg.OutputDir, tg.Config.GoLanguageVersion())
+	return err
+}
 
-	// Use tg.Config directly so the template can access .Architecture.String(), etc.
-	if err := parsedTmpl.Execute(outputFile, tg.Config); err != nil {
-		return fmt.Errorf("failed to execute template %s: %w", templatePath, err)
+// requireInEnclosingModule adds the requirements of the project's go.mod template
+// to the go.mod of the enclosing module, keeping the versions it already requires
+func (tg *TemplateGenerator) requireInEnclosingModule(templatePath string) error {
+	if tg.rendered != nil {
+		return nil
+	}
+	content, err := tg.renderTemplate(templatePath)
+	if err != nil {
+		return err
+	}
+	own, err := modfile.ParseLax("go.mod", content, nil)
+	if err != nil {
+		return fmt.Errorf("failed to parse the generated go.mod: %w", err)
 	}
 
+	repo, err := project.DetectRepo(tg.OutputDir)
+	if err != nil {
+		return err
+	}
+	if repo.ModuleRoot == "" {
+		return fmt.Errorf("%s is not inside a Go module", tg.OutputDir)
+	}
+	modPath := filepath.Join(repo.ModuleRoot, "go.mod")
+	data, err := os.ReadFile(modPath)
+	if err != nil {
+		return err
+	}
+	enclosing, err := modfile.Parse(modPath, data, nil)
+	if err != nil {
+		return err
+	}
+
+	required := map[string]bool{}
+	for _, req := range enclosing.Require {
+		required[req.Mod.Path] = true
+	}
+	for _, req := range own.Require {
+		if !required[req.Mod.Path] {
+			enclosing.AddNewRequire(req.Mod.Path, req.Mod.Version, req.Indirect)
+		}
+	}
+	enclosing.Cleanup()
+
+	out, err := enclosing.Format()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(modPath, out, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", modPath, err)
+	}
 	return nil
 }
 
+// spdxHeader returns the copyright and license comment placed at the top of Go files
+func (tg *TemplateGenerator) spdxHeader() string {
+	return fmt.Sprintf("// Copyright %d %s\n// SPDX-License-Identifier: %s\n\n",
+		tg.Config.CopyrightYear(), tg.Config.CopyrightHolder(), tg.Config.License.SPDXID())
+}
+
 func (tg *TemplateGenerator) getDestinationPath(fileType string) string {
 	arch := strings.ToLower(string(tg.Config.Architecture))
 
@@ -172,6 +364,8 @@ func (tg *TemplateGenerator) getDestinationPath(fileType string) string {
 		"models":     "internal/models/base_model.go",
 		"validator":  "internal/utils/validator.go",
 		"migrate":    "internal/migrate/migrate.go",
+		"worker":     "internal/worker/worker.go",
+		"cli":        "internal/cli/root.go",
 	}
 
 	// gocritic (ifElseChain) fix: Changed if-else to switch.
@@ -183,6 +377,8 @@ func (tg *TemplateGenerator) getDestinationPath(fileType string) string {
 		paths["handlers"] = pathHandlers
 		paths["middleware"] = pathMiddleware
 		paths["migrate"] = pathMigrate
+		paths["worker"] = pathWorker
+		paths["cli"] = pathCLI
 	case "clean":
 		paths["config"] = pathConfig
 		paths["database"] = pathDatabase
@@ -190,6 +386,8 @@ func (tg *TemplateGenerator) getDestinationPath(fileType string) string {
 		paths["handlers"] = pathHandlers
 		paths["middleware"] = pathMiddleware
 		paths["migrate"] = pathMigrate
+		paths["worker"] = pathWorker
+		paths["cli"] = pathCLI
 	case "hexagonal":
 		paths["config"] = pathConfig
 		paths["database"] = "adapters/secondary/database/connection.go"
@@ -197,6 +395,8 @@ func (tg *TemplateGenerator) getDestinationPath(fileType string) string {
 		paths["handlers"] = "adapters/primary/http/handlers.go"
 		paths["middleware"] = "adapters/primary/http/middleware.go"
 		paths["migrate"] = pathMigrate
+		paths["worker"] = "adapters/primary/worker/worker.go"
+		paths["cli"] = "adapters/primary/cli/root.go"
 	}
 
 	return paths[fileType]
@@ -206,30 +406,34 @@ func (tg *TemplateGenerator) validateConfiguration() error {
 	validationErrors := config.ValidateProjectConfig(tg.Config)
 	if len(validationErrors) > 0 {
 		// Join the errors into a single string to return as an error
-		return fmt.Errorf("configuration validation failed: %s", strings.Join(validationErrors, ", "))
+		return fmt.Errorf("configuration validation failed: %w", validationErrors)
 	}
 	return nil
 }
 
 // generateBaseFiles generates the base project files.
 func (tg *TemplateGenerator) generateBaseFiles() error {
-	baseTemplates := map[string]string{
-		"go.mod":             "base/go.mod.tmpl",
-		".gitignore":         "base/gitignore.tmpl",
-		"README.md":          "base/README.md.tmpl",
-		"Makefile":           "base/Makefile.tmpl",
-		".env":               "base/.env.tmpl",
-		".env.example":       "base/env.example.tmpl",
-		"Dockerfile":         "base/Dockerfile.tmpl",
-		"docker-compose.yml": "base/docker-compose.yml.tmpl",
-	}
-
 	for dest, src := range baseTemplates {
+		// A package of an existing module uses that module's go.mod
+		if dest == "go.mod" && tg.Config.RepoMode == config.RepoPackage {
+			if err := tg.requireInEnclosingModule(src); err != nil {
+				return err
+			}
+			continue
+		}
+		if tg.providedByPlatform(dest) {
+			continue
+		}
 		if err := tg.generateFileFromTemplate(dest, src); err != nil {
 			return err
 		}
 	}
 
+	// The configuration package is shared by every kind of project
+	if err := tg.generateFileFromTemplate(tg.getDestinationPath("config"), "base/internal/config/config.go.tmpl"); err != nil {
+		return err
+	}
+
 	// Conditionally generate validator
 	arch := strings.ToLower(string(tg.Config.Architecture))
 	if arch == "simple" || arch == "" {
@@ -243,6 +447,12 @@ func (tg *TemplateGenerator) generateBaseFiles() error {
 	return nil
 }
 
+// providedByPlatform reports whether the platform of a service provides the
+// base file dest for all its services, like the Docker Compose file
+func (tg *TemplateGenerator) providedByPlatform(dest string) bool {
+	return tg.Config.Platform != "" && dest == "docker-compose.yml"
+}
+
 // generateFrameworkFiles generates the framework-specific files.
 func (tg *TemplateGenerator) generateFrameworkFiles() error {
 	framework := string(tg.Config.Framework)
@@ -268,8 +478,6 @@ func (tg *TemplateGenerator) generateFrameworkFiles() error {
 			destPath = "cmd/api/main.go"
 		case "routes.go.tmpl":
 			destPath = tg.getDestinationPath("routes")
-		case "config.go.tmpl":
-			destPath = tg.getDestinationPath("config")
 		case "handlers.go.tmpl":
 			destPath = tg.getDestinationPath("handlers")
 		case "middleware.go.tmpl":
@@ -285,6 +493,35 @@ func (tg *TemplateGenerator) generateFrameworkFiles() error {
 	return nil
 }
 
+// generateKindFiles generates the worker and CLI binaries of the project kind.
+// The API binary is generated with the framework files.
+func (tg *TemplateGenerator) generateKindFiles() error {
+	files := map[string]string{}
+	if tg.Config.Kind.HasWorker() {
+		files["cmd/worker/main.go"] = "worker/main.go.tmpl"
+		files[tg.getDestinationPath("worker")] = "worker/worker.go.tmpl"
+	}
+	if tg.Config.Kind.HasCLI() {
+		files["cmd/cli/main.go"] = "cli/main.go.tmpl"
+		files[tg.getDestinationPath("cli")] = "cli/root.go.tmpl"
+	}
+
+	for dest, src := range files {
+		templatePath := filepath.Join(kindsDir, src)
+		if err := tg.generateFileFromTemplate(dest, templatePath); err != nil {
+			return fmt.Errorf("failed to generate %s file from %s: %w", tg.Config.Kind, templatePath, err)
+		}
+	}
+	return nil
+}
+
+// isHTTPTemplate reports whether an architecture template belongs to the HTTP
+// layer, which only API projects have
+func isHTTPTemplate(templatePath string) bool {
+	return strings.Contains(templatePath, "/handlers/") ||
+		strings.Contains(templatePath, "/adapters/primary/http/")
+}
+
 // generateDatabaseConfig generates the database configuration files.
 func (tg *TemplateGenerator) generateDatabaseConfig() error {
 	tool := strings.ToLower(tg.Config.Tool.String())
@@ -506,6 +743,16 @@ func (tg *TemplateGenerator) generateDevOpsFiles() error {
 	return nil
 }
 
+// generateLicense generates the LICENSE file for the selected license.
+func (tg *TemplateGenerator) generateLicense() error {
+	if tg.Config.License == "" {
+		return nil
+	}
+
+	templatePath := filepath.Join(licensesDir, string(tg.Config.License)+".tmpl")
+	return tg.generateFileFromTemplate("LICENSE", templatePath)
+}
+
 func (tg *TemplateGenerator) generateHelmChart() error {
 	tempDir, err := os.MkdirTemp("", "goback-helm-")
 	if err != nil {
@@ -591,7 +838,7 @@ func (tg *TemplateGenerator) generateHelmChart() error {
 
 	releaseOptions := chartutil.ReleaseOptions{
 		Name:      tg.Config.ProjectName,
-		Namespace: "default",
+		Namespace: fmt.Sprint(tg.Config.Value("kubernetes.namespace", "default")),
 		Revision:  1,
 		IsInstall: true,
 	}
@@ -624,13 +871,10 @@ func (tg *TemplateGenerator) generateHelmChart() error {
 		// The path from `engine.Render` is relative to the chart root, e.g., `my-chart/templates/service.yaml`
 		// We want to strip the chart name prefix.
 		relPath := strings.TrimPrefix(path, chart.Name()+"/")
-		destPath := filepath.Join(tg.OutputDir, devopsDir, helmDir, relPath)
+		destPath := filepath.Join(devopsDir, helmDir, relPath)
 
-		if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
-			return fmt.Errorf("failed to create directory for %s: %w", destPath, err)
-		}
-		if err := os.WriteFile(destPath, []byte(content), 0644); err != nil {
-			return fmt.Errorf("failed to write rendered file %s: %w", destPath, err)
+		if err := tg.writeFile(destPath, []byte(content)); err != nil {
+			return fmt.Errorf("failed to write rendered helm file: %w", err)
 		}
 	}
 
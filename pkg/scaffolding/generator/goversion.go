@@ -0,0 +1,132 @@
+// pkg/scaffolding/generator/goversion.go
+
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"strconv"
+
+	"github.com/NarmadaWeb/goback/pkg/config"
+)
+
+// languageFeature is a Go language or standard library feature that is only
+// available from a given release
+type languageFeature struct {
+	Name    string
+	Version string
+	Uses    func(node ast.Node) bool
+}
+
+// serveMuxPattern matches http.ServeMux patterns with a method, e.g. "GET /users/{id}"
+var serveMuxPattern = regexp.MustCompile(`^(GET|HEAD|POST|PUT|PATCH|DELETE|CONNECT|OPTIONS|TRACE) /`)
+
+// languageFeatures are the features checked in generated Go files
+var languageFeatures = []languageFeature{
+	{Name: "the log/slog package", Version: "1.21", Uses: importsPackage("log/slog")},
+	{Name: "the slices package", Version: "1.21", Uses: importsPackage("slices")},
+	{Name: "the maps package", Version: "1.21", Uses: importsPackage("maps")},
+	{Name: "the cmp package", Version: "1.21", Uses: importsPackage("cmp")},
+	{Name: "the min, max and clear built-ins", Version: "1.21", Uses: callsBuiltin("min", "max", "clear")},
+	{Name: "http.ServeMux routing patterns", Version: "1.22", Uses: usesServeMuxPatterns},
+	{Name: "range over integers", Version: "1.22", Uses: rangesOverInt},
+	{Name: "the iter package", Version: "1.23", Uses: importsPackage("iter")},
+	{Name: "the unique package", Version: "1.23", Uses: importsPackage("unique")},
+	{Name: "the weak package", Version: "1.24", Uses: importsPackage("weak")},
+}
+
+// checkLanguageFeatures returns an error when a generated Go file uses a feature
+// that is newer than the project's Go version. Files that do not parse are not checked.
+func (tg *TemplateGenerator) checkLanguageFeatures(path string, content []byte) error {
+	file, err := parser.ParseFile(token.NewFileSet(), path, content, 0)
+	if err != nil {
+		return nil
+	}
+
+	goVersion := tg.Config.GoLanguageVersion()
+	for _, feature := range languageFeatures {
+		if config.CompareGoVersions(goVersion, feature.Version) >= 0 {
+			continue
+		}
+		used := false
+		ast.Inspect(file, func(node ast.Node) bool {
+			used = used || (node != nil && feature.Uses(node))
+			return !used
+		})
+		if used {
+			return fmt.Errorf("%s uses %s, which needs Go %s or newer, but the project targets Go %s (see --go-version)",
+				path, feature.Name, feature.Version, goVersion)
+		}
+	}
+	return nil
+}
+
+func importsPackage(importPath string) func(ast.Node) bool {
+	return func(node ast.Node) bool {
+		spec, ok := node.(*ast.ImportSpec)
+		if !ok {
+			return false
+		}
+		path, err := strconv.Unquote(spec.Path.Value)
+		return err == nil && path == importPath
+	}
+}
+
+// callsBuiltin reports calls of the named built-ins that are not declared in the file
+func callsBuiltin(names ...string) func(ast.Node) bool {
+	return func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		ident, ok := call.Fun.(*ast.Ident)
+		if !ok || ident.Obj != nil {
+			return false
+		}
+		for _, name := range names {
+			if ident.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// usesServeMuxPatterns reports Handle and HandleFunc calls with a method pattern, and PathValue calls
+func usesServeMuxPatterns(node ast.Node) bool {
+	call, ok := node.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	switch sel.Sel.Name {
+	case "PathValue":
+		return true
+	case "Handle", "HandleFunc":
+		if len(call.Args) == 0 {
+			return false
+		}
+		lit, ok := call.Args[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return false
+		}
+		pattern, err := strconv.Unquote(lit.Value)
+		return err == nil && serveMuxPattern.MatchString(pattern)
+	}
+	return false
+}
+
+func rangesOverInt(node ast.Node) bool {
+	stmt, ok := node.(*ast.RangeStmt)
+	if !ok {
+		return false
+	}
+	lit, ok := stmt.X.(*ast.BasicLit)
+	return ok && lit.Kind == token.INT
+}
@@ -0,0 +1,262 @@
+// pkg/scaffolding/generator/module.go
+
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NarmadaWeb/goback/pkg/project"
+	"golang.org/x/mod/modfile"
+)
+
+// IgnoreFile lists the files of a reference module that are not copied
+const IgnoreFile = ".gobackignore"
+
+// alwaysIgnored are never copied from a reference module
+var alwaysIgnored = []string{".git/", ".goback/"}
+
+// CopyModule copies the Go module in src into the output directory as a new
+// project. The module path in go.mod and every import of the old module path
+// are rewritten to the configured module path; files matched by .gobackignore
+// are skipped. Without a configured Go version the module keeps its own. The
+// copy is recorded in the project manifest.
+func (tg *TemplateGenerator) CopyModule(src string) error {
+	mod, err := project.ReadGoMod(src)
+	if err != nil {
+		return err
+	}
+	setGoVersion := tg.Config.GoVersion != ""
+	if !setGoVersion {
+		tg.Config.GoVersion = mod.GoVersion
+		if mod.Toolchain != "" {
+			tg.Config.GoVersion = mod.Toolchain
+		}
+	}
+	ignore, err := loadIgnoreRules(filepath.Join(src, IgnoreFile))
+	if err != nil {
+		return err
+	}
+
+	err = filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+		if relPath == "." {
+			return nil
+		}
+
+		if ignore.Match(relPath, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		switch {
+		case relPath == "go.mod":
+			content, err = tg.rewriteGoMod(content, setGoVersion)
+		case strings.HasSuffix(relPath, ".go"):
+			content, err = rewriteImports(relPath, content, mod.Module, tg.Config.ModulePath)
+		}
+		if err != nil {
+			return err
+		}
+		if err := tg.writeFile(relPath, content); err != nil {
+			return err
+		}
+
+		// Keep scripts executable
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if tg.rendered == nil && info.Mode()&0111 != 0 && !slices.Contains(tg.skipped, relPath) {
+			return os.Chmod(filepath.Join(tg.OutputDir, filepath.FromSlash(relPath)), info.Mode().Perm())
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return tg.writeCopiedManifest()
+}
+
+// writeCopiedManifest records a project copied from a module or template pack.
+// The parts of the stack that were not configured are detected from the
+// written files, so 'goback add' can extend the project.
+func (tg *TemplateGenerator) writeCopiedManifest() error {
+	cfg := tg.Config
+	if cfg.CreatedAt.IsZero() {
+		cfg.CreatedAt = time.Now()
+	}
+	// Detect also returns what it found when it misses part of the stack
+	if detected, _ := project.Detect(tg.OutputDir); detected != nil {
+		if cfg.Kind == "" {
+			cfg.Kind = detected.Kind
+		}
+		if cfg.Framework == "" && cfg.Kind.HasAPI() {
+			cfg.Framework = detected.Framework
+		}
+		if cfg.Database == "" {
+			cfg.Database = detected.Database
+		}
+		if cfg.Tool == "" {
+			cfg.Tool = detected.Tool
+		}
+		if cfg.Architecture == "" {
+			cfg.Architecture = detected.Architecture
+		}
+		if !cfg.DevOps.Enabled {
+			cfg.DevOps = detected.DevOps
+		}
+	}
+	return tg.writeManifest()
+}
+
+// rewriteGoMod sets the module path and, when setGoVersion is true, the go and toolchain directives
+func (tg *TemplateGenerator) rewriteGoMod(content []byte, setGoVersion bool) ([]byte, error) {
+	file, err := modfile.Parse("go.mod", content, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := file.AddModuleStmt(tg.Config.ModulePath); err != nil {
+		return nil, err
+	}
+	if setGoVersion {
+		if err := file.AddGoStmt(tg.Config.GoLanguageVersion()); err != nil {
+			return nil, err
+		}
+		if err := file.AddToolchainStmt("go" + tg.Config.GoToolchainVersion()); err != nil {
+			return nil, err
+		}
+	}
+	file.Cleanup()
+	return modfile.Format(file.Syntax), nil
+}
+
+// rewriteImports rewrites the imports of oldPath and its packages to newPath.
+// Files without such imports, or that do not parse, are returned unchanged.
+func rewriteImports(name string, content []byte, oldPath, newPath string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, name, content, parser.ParseComments)
+	if err != nil {
+		return content, nil
+	}
+
+	changed := false
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		if importPath == oldPath || strings.HasPrefix(importPath, oldPath+"/") {
+			spec.Path.Value = strconv.Quote(newPath + strings.TrimPrefix(importPath, oldPath))
+			changed = true
+		}
+	}
+	if !changed {
+		return content, nil
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, fmt.Errorf("failed to format %s: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ignoreRule is a pattern of an ignore file
+type ignoreRule struct {
+	pattern  string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// ignoreRules holds the patterns of a .gobackignore file. The syntax follows
+// .gitignore: patterns use path.Match syntax, a trailing slash matches only
+// directories, a pattern with a slash is matched against the whole path and
+// a leading ! re-includes a path. The last matching pattern wins.
+type ignoreRules []ignoreRule
+
+// loadIgnoreRules reads the ignore file; a missing file ignores only the defaults
+func loadIgnoreRules(file string) (ignoreRules, error) {
+	lines := slices.Clone(alwaysIgnored)
+	data, err := os.ReadFile(file)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	var rules ignoreRules
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule := ignoreRule{}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimSuffix(line, "/")
+		}
+		rule.anchored = strings.Contains(line, "/")
+		rule.pattern = strings.TrimPrefix(line, "/")
+		if _, err := path.Match(rule.pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q in %s: %w", line, IgnoreFile, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// Match reports whether the slash separated path relative to the module root is ignored
+func (rules ignoreRules) Match(relPath string, isDir bool) bool {
+	ignored := false
+	for _, rule := range rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		name := path.Base(relPath)
+		if rule.anchored {
+			name = relPath
+		}
+		if matched, _ := path.Match(rule.pattern, name); matched {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
@@ -0,0 +1,119 @@
+// pkg/scaffolding/generator/module_test.go
+
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NarmadaWeb/goback/pkg/config"
+	"github.com/NarmadaWeb/goback/pkg/project"
+)
+
+func TestIgnoreRulesMatch(t *testing.T) {
+	file := filepath.Join(t.TempDir(), IgnoreFile)
+	content := `# generated and local files
+*.log
+bin/
+/vendor
+docs/*.md
+!docs/README.md
+testdata/fixtures/
+`
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := loadIgnoreRules(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{".git", true, true},
+		{".goback", true, true},
+		{"sub/.git", true, true},
+		{".gitignore", false, false},
+		{"app.log", false, true},
+		{"logs/app.log", false, true},
+		{"app.log.go", false, false},
+		{"bin", true, true},
+		{"cmd/bin", true, true},
+		{"bin", false, false},
+		{"vendor", true, true},
+		{"vendor", false, true},
+		{"internal/vendor", true, false},
+		{"docs/guide.md", false, true},
+		{"docs/README.md", false, false},
+		{"docs/sub/guide.md", false, false},
+		{"guide.md", false, false},
+		{"testdata/fixtures", true, true},
+		{"testdata", true, false},
+		{"main.go", false, false},
+	}
+	for _, tt := range tests {
+		if got := rules.Match(tt.path, tt.isDir); got != tt.want {
+			t.Errorf("Match(%q, %v) = %v, want %v", tt.path, tt.isDir, got, tt.want)
+		}
+	}
+}
+
+func TestLoadIgnoreRules(t *testing.T) {
+	dir := t.TempDir()
+
+	// A missing file ignores only the defaults
+	rules, err := loadIgnoreRules(filepath.Join(dir, IgnoreFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != len(alwaysIgnored) {
+		t.Errorf("got %d rules without %s, want %d", len(rules), IgnoreFile, len(alwaysIgnored))
+	}
+
+	file := filepath.Join(dir, IgnoreFile)
+	if err := os.WriteFile(file, []byte("[invalid\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadIgnoreRules(file); err == nil {
+		t.Error("got no error for an invalid pattern")
+	}
+}
+
+// TestCopyModuleRecordsManifest checks that a copied module is recorded with
+// the stack detected from its files
+func TestCopyModuleRecordsManifest(t *testing.T) {
+	src := t.TempDir()
+	files := map[string]string{
+		"go.mod":  "module example.com/reference\n\ngo 1.22\n\nrequire github.com/go-chi/chi/v5 v5.0.12\n",
+		"main.go": "package main\n\nimport _ \"example.com/reference/internal/config\"\n\nfunc main() {}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(src, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dir := filepath.Join(t.TempDir(), "orders")
+	cfg := &config.ProjectConfig{ProjectName: "orders", ModulePath: "example.com/orders", OutputDir: dir}
+	if err := NewTemplateGenerator(cfg).CopyModule(src); err != nil {
+		t.Fatalf("CopyModule() error = %v", err)
+	}
+
+	manifest, err := project.LoadManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Config.ModulePath != "example.com/orders" || manifest.Config.Framework != config.FrameworkChi {
+		t.Errorf("recorded module %s with framework %q, want example.com/orders with chi",
+			manifest.Config.ModulePath, manifest.Config.Framework)
+	}
+	for name := range files {
+		if state := manifest.State(dir, name); state != project.FilePristine {
+			t.Errorf("%s is %s, want pristine", name, state)
+		}
+	}
+}
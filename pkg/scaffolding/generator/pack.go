@@ -0,0 +1,332 @@
+// pkg/scaffolding/generator/pack.go
+
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/NarmadaWeb/goback/pkg/project"
+	"github.com/iancoleman/strcase"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// PackDescriptor is the descriptor file at the root of a template pack
+	PackDescriptor = "goback-template.yaml"
+	// packFilesDir holds the files of a template pack
+	packFilesDir = "files"
+	// templateSuffix marks the pack files that are rendered; other files are copied
+	templateSuffix = ".tmpl"
+)
+
+// Pack describes a template pack exported from a project
+type Pack struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	// ProjectName and ModulePath are the values the pack was exported from
+	ProjectName string    `yaml:"project_name"`
+	ModulePath  string    `yaml:"module_path"`
+	GoVersion   string    `yaml:"go_version,omitempty"`
+	CreatedAt   time.Time `yaml:"created_at"`
+}
+
+// ExportPack turns the project in src into a template pack in dest. The module
+// path and the project name, in kebab, snake and title case, are replaced with
+// template expressions in the file contents and paths. Files matched by
+// .gobackignore are left out. It returns the number of files exported.
+func ExportPack(src, dest string, pack *Pack) (int, error) {
+	mod, err := project.ReadGoMod(src)
+	if err != nil {
+		return 0, err
+	}
+	pack.ModulePath = mod.Module
+	pack.GoVersion = mod.GoVersion
+	if mod.Toolchain != "" {
+		pack.GoVersion = mod.Toolchain
+	}
+	pack.ProjectName = project.ModuleName(mod.Module)
+	if manifest, err := project.LoadManifest(src); err == nil {
+		pack.ProjectName = manifest.Config.ProjectName
+	}
+	pack.CreatedAt = time.Now()
+
+	ignore, err := loadIgnoreRules(filepath.Join(src, IgnoreFile))
+	if err != nil {
+		return 0, err
+	}
+	templatize := packReplacer(pack.ModulePath, pack.ProjectName)
+
+	count := 0
+	err = filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+		if relPath == "." {
+			return nil
+		}
+
+		if ignore.Match(relPath, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+
+		destPath := templatize(relPath)
+		if isText(content) {
+			content = []byte(templatize(string(content)))
+			destPath += templateSuffix
+		}
+
+		fullDestPath := filepath.Join(dest, packFilesDir, filepath.FromSlash(destPath))
+		if err := os.MkdirAll(filepath.Dir(fullDestPath), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", fullDestPath, err)
+		}
+		if err := os.WriteFile(fullDestPath, content, info.Mode().Perm()|0600); err != nil {
+			return fmt.Errorf("failed to write file %s: %w", fullDestPath, err)
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		return count, err
+	}
+
+	data, err := yaml.Marshal(pack)
+	if err != nil {
+		return count, err
+	}
+	return count, os.WriteFile(filepath.Join(dest, PackDescriptor), data, 0644)
+}
+
+// LoadPack reads the descriptor of the template pack in dir
+func LoadPack(dir string) (*Pack, error) {
+	data, err := os.ReadFile(filepath.Join(dir, PackDescriptor))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%s is not a template pack, %s is missing", dir, PackDescriptor)
+		}
+		return nil, err
+	}
+	pack := &Pack{}
+	if err := yaml.Unmarshal(data, pack); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", PackDescriptor, err)
+	}
+	return pack, nil
+}
+
+// GenerateFromPack renders the template pack in dir into the output directory
+// and records the project in its manifest. Without a configured Go version the
+// project keeps the version of the pack.
+func (tg *TemplateGenerator) GenerateFromPack(dir string) error {
+	pack, err := LoadPack(dir)
+	if err != nil {
+		return err
+	}
+	if tg.Config.GoVersion == "" {
+		tg.Config.GoVersion = pack.GoVersion
+	}
+
+	filesDir := filepath.Join(dir, packFilesDir)
+	err = filepath.WalkDir(filesDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		relPath, err := filepath.Rel(filesDir, p)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+
+		destPath, err := tg.renderPackTemplate(relPath, []byte(relPath))
+		if err != nil {
+			return err
+		}
+		if strings.HasSuffix(relPath, templateSuffix) {
+			destPath = bytes.TrimSuffix(destPath, []byte(templateSuffix))
+			if content, err = tg.renderPackTemplate(relPath, content); err != nil {
+				return err
+			}
+		}
+
+		if err := tg.writeFile(string(destPath), content); err != nil {
+			return err
+		}
+		if tg.rendered == nil && info.Mode()&0111 != 0 {
+			return os.Chmod(filepath.Join(tg.OutputDir, filepath.FromSlash(string(destPath))), info.Mode().Perm())
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return tg.writeCopiedManifest()
+}
+
+// renderPackTemplate executes a template of a pack with the project configuration
+func (tg *TemplateGenerator) renderPackTemplate(name string, text []byte) ([]byte, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs()).Parse(string(text))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+	var content bytes.Buffer
+	if err := tmpl.Execute(&content, tg.Config); err != nil {
+		return nil, fmt.Errorf("failed to execute template %s: %w", name, err)
+	}
+	return content.Bytes(), nil
+}
+
+// layoutNames are names the generated layout uses by itself, like cmd/api,
+// /api/v1 or the api service of docker-compose.yml. A project named like one
+// of them cannot be told apart from the layout, so its name is not templated.
+var layoutNames = []string{
+	"api", "app", "cli", "cmd", "config", "database", "db", "domain", "handlers", "health",
+	"internal", "main", "models", "mysql", "nginx", "pkg", "postgres", "routes", "server",
+	"services", "user", "users", "v1", "worker",
+}
+
+// IsLayoutName reports whether a project name is also a name of the generated
+// layout, and so is kept as it is in an exported pack
+func IsLayoutName(projectName string) bool {
+	return slices.Contains(layoutNames, strings.ToLower(strcase.ToKebab(projectName)))
+}
+
+// packReplacer returns a function that escapes template delimiters and
+// replaces the module path and the project name with template expressions.
+// The project name, also in upper case, only matches as a whole word (a
+// letter or digit must not touch it), so that a short name does not replace
+// parts of other identifiers. A project name that is a layout name is left
+// as it is.
+func packReplacer(modulePath, projectName string) func(string) string {
+	replacements := []replacement{
+		{old: "{{", new: `{{"{{"}}`},
+		{old: "}}", new: `{{"}}"}}`},
+		{old: modulePath, new: "{{.ModulePath}}"},
+	}
+	seen := map[string]bool{}
+	if IsLayoutName(projectName) {
+		projectName = ""
+	}
+	for _, name := range []struct{ value, expr string }{
+		{strcase.ToKebab(projectName), "{{kebabCase .ProjectName}}"},
+		{strcase.ToSnake(projectName), "{{snakeCase .ProjectName}}"},
+		{titleCase(projectName), "{{titleCase .ProjectName}}"},
+		{strings.ToUpper(strcase.ToKebab(projectName)), "{{upper (kebabCase .ProjectName)}}"},
+		{strings.ToUpper(strcase.ToSnake(projectName)), "{{upper (snakeCase .ProjectName)}}"},
+	} {
+		if name.value == "" || seen[name.value] {
+			continue
+		}
+		seen[name.value] = true
+		replacements = append(replacements, replacement{old: name.value, new: name.expr, wholeWord: true})
+	}
+	replace := newReplacer(replacements)
+	return func(s string) string {
+		// A literal { right before an inserted expression, as in ${NAME_PORT},
+		// would open the action early; only a single { can be left there, as
+		// {{ is escaped
+		return strings.ReplaceAll(replace(s), "{{{", `{{"{"}}{{`)
+	}
+}
+
+// replacement replaces old with new; a whole word replacement only matches
+// where no letter or digit touches old
+type replacement struct {
+	old, new  string
+	wholeWord bool
+}
+
+// newReplacer returns a function that applies the replacements in a single
+// pass, trying them in order at every position
+func newReplacer(replacements []replacement) func(string) string {
+	return func(s string) string {
+		var b strings.Builder
+		for i := 0; i < len(s); {
+			matched := false
+			for _, r := range replacements {
+				if !strings.HasPrefix(s[i:], r.old) {
+					continue
+				}
+				if r.wholeWord && (isWordByteBefore(s, i) || isWordByteAt(s, i+len(r.old))) {
+					continue
+				}
+				b.WriteString(r.new)
+				i += len(r.old)
+				matched = true
+				break
+			}
+			if !matched {
+				b.WriteByte(s[i])
+				i++
+			}
+		}
+		return b.String()
+	}
+}
+
+func isWordByteBefore(s string, i int) bool {
+	return i > 0 && isWordByte(s[i-1])
+}
+
+func isWordByteAt(s string, i int) bool {
+	return i < len(s) && isWordByte(s[i])
+}
+
+func isWordByte(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// titleCase turns a project name like billing-service into Billing Service
+func titleCase(s string) string {
+	words := strings.Fields(strcase.ToDelimited(s, ' '))
+	for i, word := range words {
+		r, size := utf8.DecodeRuneInString(word)
+		words[i] = string(unicode.ToUpper(r)) + word[size:]
+	}
+	return strings.Join(words, " ")
+}
+
+// isText reports whether content looks like a text file that can be templated
+func isText(content []byte) bool {
+	return utf8.Valid(content) && !bytes.Contains(content, []byte{0})
+}
@@ -0,0 +1,168 @@
+// pkg/scaffolding/generator/pack_test.go
+
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"text/template"
+
+	"github.com/NarmadaWeb/goback/pkg/config"
+	"github.com/NarmadaWeb/goback/pkg/project"
+)
+
+func TestPackReplacer(t *testing.T) {
+	tests := []struct {
+		name       string
+		modulePath string
+		project    string
+		in         string
+		want       string
+	}{
+		{"module path", "github.com/acme/billing-service", "billing-service",
+			"module github.com/acme/billing-service", "module {{.ModulePath}}"},
+		{"package import", "github.com/acme/billing-service", "billing-service",
+			`import "github.com/acme/billing-service/internal/config"`, `import "{{.ModulePath}}/internal/config"`},
+		{"kebab case", "github.com/acme/billing-service", "billing-service",
+			"BINARY=billing-service", "BINARY={{kebabCase .ProjectName}}"},
+		{"snake case", "github.com/acme/billing-service", "billing-service",
+			"DB_NAME=billing_service", "DB_NAME={{snakeCase .ProjectName}}"},
+		{"title case", "github.com/acme/billing-service", "billing-service",
+			"# Billing Service", "# {{titleCase .ProjectName}}"},
+		{"upper snake case", "github.com/acme/billing-service", "billing-service",
+			"BILLING_SERVICE_PORT=8080", "{{upper (snakeCase .ProjectName)}}_PORT=8080"},
+		{"suffix", "github.com/acme/billing-service", "billing-service",
+			"container_name: billing-service-db", "container_name: {{kebabCase .ProjectName}}-db"},
+		{"part of a word", "github.com/acme/billing-service", "billing-service",
+			"mybilling-service billing-services", "mybilling-service billing-services"},
+		{"short name", "example.com/shop", "shop",
+			"rapid shop, shopKey", "rapid {{kebabCase .ProjectName}}, shopKey"},
+		{"template delimiters", "example.com/shop", "shop",
+			"{{ .Values.port }}", `{{"{{"}} .Values.port {{"}}"}}`},
+		{"brace before the name", "example.com/shop", "shop",
+			"${SHOP_PORT:-8080}", `${{"{"}}{{upper (kebabCase .ProjectName)}}_PORT:-8080}`},
+		{"layout name", "example.com/api", "api",
+			"module example.com/api\ncmd/api /api/v1 api-api ${API_PORT:-8080}",
+			"module {{.ModulePath}}\ncmd/api /api/v1 api-api ${API_PORT:-8080}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := packReplacer(tt.modulePath, tt.project)(tt.in); got != tt.want {
+				t.Errorf("packReplacer(%q, %q)(%q) = %q, want %q", tt.modulePath, tt.project, tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewReplacer(t *testing.T) {
+	tests := []struct {
+		name         string
+		replacements []replacement
+		in           string
+		want         string
+	}{
+		{"single pass", []replacement{{old: "a", new: "b"}, {old: "b", new: "a"}}, "abba", "baab"},
+		{"first match wins", []replacement{{old: "ab", new: "1"}, {old: "a", new: "2"}}, "aab", "21"},
+		{"whole word", []replacement{{old: "go", new: "Go", wholeWord: true}}, "go gopher ago go-kit", "Go gopher ago Go-kit"},
+		{"no match", []replacement{{old: "x", new: "y"}}, "abc", "abc"},
+		{"empty", []replacement{{old: "x", new: "y"}}, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newReplacer(tt.replacements)(tt.in); got != tt.want {
+				t.Errorf("newReplacer(...)(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPackReplacerRendersBack exports text whose project name also shows up
+// as ordinary text and renders it for another project
+func TestPackReplacerRendersBack(t *testing.T) {
+	tests := []struct {
+		name       string
+		modulePath string
+		project    string
+		in         string
+		want       string
+	}{
+		{"project name", "example.com/shop", "shop",
+			"container_name: shop-postgres\n- \"${SHOP_PORT:-8080}:8080\"\nBINARY=bin/shop {x}",
+			"container_name: orders-postgres\n- \"${ORDERS_PORT:-8080}:8080\"\nBINARY=bin/orders {x}"},
+		{"layout name", "example.com/api", "api",
+			"module example.com/api\ncontainer_name: api-api\n- \"${API_PORT:-8080}:8080\"\ngo build ./cmd/api\nr.Route(\"/api/v1\")",
+			"module example.com/orders\ncontainer_name: api-api\n- \"${API_PORT:-8080}:8080\"\ngo build ./cmd/api\nr.Route(\"/api/v1\")"},
+		{"template text", "example.com/shop", "shop",
+			"{{ .Values.port }} {{{shop}}}",
+			"{{ .Values.port }} {{{orders}}}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text := packReplacer(tt.modulePath, tt.project)(tt.in)
+			tmpl, err := template.New(tt.name).Funcs(templateFuncs()).Parse(text)
+			if err != nil {
+				t.Fatalf("exported text %q does not parse: %v", text, err)
+			}
+			var got bytes.Buffer
+			if err := tmpl.Execute(&got, &config.ProjectConfig{ProjectName: "orders", ModulePath: "example.com/orders"}); err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("rendered %q, want %q", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+// TestGenerateFromPack exports a project, generates another one from the pack
+// and checks that it is recorded in the project manifest
+func TestGenerateFromPack(t *testing.T) {
+	src := t.TempDir()
+	files := map[string]string{
+		"go.mod":    "module example.com/shop/v2\n\ngo 1.22\n\nrequire github.com/labstack/echo/v4 v4.12.0\n",
+		"main.go":   "package main\n\nimport _ \"example.com/shop/v2/internal/config\"\n\nfunc main() {}\n",
+		"README.md": "# Shop\n\nBINARY=shop\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(src, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	packDir := filepath.Join(t.TempDir(), "pack")
+	pack := &Pack{Name: "shop"}
+	if _, err := ExportPack(src, packDir, pack); err != nil {
+		t.Fatalf("ExportPack() error = %v", err)
+	}
+	if pack.ProjectName != "shop" {
+		t.Errorf("exported project name = %q, want shop", pack.ProjectName)
+	}
+
+	dir := filepath.Join(t.TempDir(), "orders")
+	cfg := &config.ProjectConfig{ProjectName: "orders", ModulePath: "example.com/orders", OutputDir: dir}
+	if err := NewTemplateGenerator(cfg).GenerateFromPack(packDir); err != nil {
+		t.Fatalf("GenerateFromPack() error = %v", err)
+	}
+
+	readme, err := os.ReadFile(filepath.Join(dir, "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "# Orders\n\nBINARY=orders\n"; string(readme) != want {
+		t.Errorf("README.md = %q, want %q", readme, want)
+	}
+	manifest, err := project.LoadManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Config.ModulePath != "example.com/orders" || manifest.Config.Framework != config.FrameworkEcho {
+		t.Errorf("recorded module %s with framework %q, want example.com/orders with echo",
+			manifest.Config.ModulePath, manifest.Config.Framework)
+	}
+	for name := range files {
+		if state := manifest.State(dir, name); state != project.FilePristine {
+			t.Errorf("%s is %s, want pristine", name, state)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// pkg/scaffolding/generator/patch.go
+
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// sourcePatch collects text insertions and replacements in an existing Go file. Edits are
+// made at byte offsets of the parsed syntax tree so that the user's own code
+// and comments are left untouched, and the result is gofmt'ed.
+type sourcePatch struct {
+	path  string
+	src   []byte
+	fset  *token.FileSet
+	file  *ast.File
+	edits []sourceEdit
+	added map[string]string // import path -> name of imports added by importName
+}
+
+// sourceEdit replaces the bytes from offset up to end with text; an insertion has end == offset
+type sourceEdit struct {
+	offset int
+	end    int
+	text   string
+}
+
+var versionSuffix = regexp.MustCompile(`^v[0-9]+$`)
+
+// parseSourcePatch parses the source of the Go file at filePath
+func parseSourcePatch(filePath string, src []byte) (*sourcePatch, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filePath, err)
+	}
+	return &sourcePatch{path: filePath, src: src, fset: fset, file: file, added: map[string]string{}}, nil
+}
+
+// insert adds text at the position of pos
+func (p *sourcePatch) insert(pos token.Pos, text string) {
+	offset := p.fset.Position(pos).Offset
+	p.edits = append(p.edits, sourceEdit{offset: offset, end: offset, text: text})
+}
+
+// replace replaces the source from pos up to end with text
+func (p *sourcePatch) replace(pos, end token.Pos, text string) {
+	p.edits = append(p.edits, sourceEdit{offset: p.fset.Position(pos).Offset, end: p.fset.Position(end).Offset, text: text})
+}
+
+// funcDecl returns the top level function with the given name
+func (p *sourcePatch) funcDecl(name string) *ast.FuncDecl {
+	for _, decl := range p.file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == name {
+			return fn
+		}
+	}
+	return nil
+}
+
+// importName returns the identifier under which importPath is available in
+// the file, adding the import when it is missing. A conflicting package name
+// is resolved with an alias.
+func (p *sourcePatch) importName(importPath, pkgName string) string {
+	used := map[string]bool{}
+	for _, spec := range p.file.Imports {
+		specPath, _ := strconv.Unquote(spec.Path.Value)
+		name := defaultImportName(specPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if specPath == importPath {
+			return name
+		}
+		used[name] = true
+	}
+	for addedPath, name := range p.added {
+		if addedPath == importPath {
+			return name
+		}
+		used[name] = true
+	}
+
+	name := pkgName
+	if used[name] {
+		parent := path.Base(path.Dir(importPath))
+		name = strings.NewReplacer("-", "_", ".", "_").Replace(parent) + "_" + pkgName
+	}
+
+	spec := strconv.Quote(importPath)
+	if name != defaultImportName(importPath) {
+		spec = name + " " + spec
+	}
+	p.addImport(spec)
+	p.added[importPath] = name
+	return name
+}
+
+// addImport inserts an import spec into the first import declaration
+func (p *sourcePatch) addImport(spec string) {
+	for _, decl := range p.file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		if gen.Lparen.IsValid() {
+			p.insert(gen.Rparen, "\t"+spec+"\n")
+		} else {
+			// Turn a single import into a group
+			p.insert(gen.Specs[0].Pos(), "(\n\t")
+			p.insert(gen.End(), "\n\t"+spec+"\n)")
+		}
+		return
+	}
+	p.insert(p.file.Name.End(), "\n\nimport "+spec)
+}
+
+// apply returns the patched and formatted source
+func (p *sourcePatch) apply() ([]byte, error) {
+	edits := append([]sourceEdit(nil), p.edits...)
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].offset < edits[j].offset })
+
+	var b strings.Builder
+	last := 0
+	for _, edit := range edits {
+		// An insertion into replaced source goes after the replacement
+		edit.offset = max(edit.offset, last)
+		b.Write(p.src[last:edit.offset])
+		b.WriteString(edit.text)
+		last = max(edit.end, last)
+	}
+	b.Write(p.src[last:])
+
+	formatted, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to format patched %s: %w", p.path, err)
+	}
+	return formatted, nil
+}
+
+// defaultImportName guesses the package name of an import path, skipping
+// major version suffixes such as /v2
+func defaultImportName(importPath string) string {
+	name := path.Base(importPath)
+	if versionSuffix.MatchString(name) {
+		name = path.Base(path.Dir(importPath))
+	}
+	return strings.TrimPrefix(name, "go-")
+}
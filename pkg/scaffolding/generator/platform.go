@@ -0,0 +1,158 @@
+// pkg/scaffolding/generator/platform.go
+
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/NarmadaWeb/goback/pkg/config"
+	"github.com/NarmadaWeb/goback/pkg/project"
+	"github.com/NarmadaWeb/goback/pkg/scaffolding"
+)
+
+// platformDir holds the templates of a monorepo platform. Files ending in
+// .tmpl are rendered with the platform, the others (Helm templates) are copied.
+const platformDir = "platform"
+
+// platformServiceFiles are the platform files that list the services, rewritten when a service is added
+var platformServiceFiles = []string{"docker-compose.yml", "deploy/helm/values.yaml"}
+
+// PlatformGenerator generates a monorepo platform: a root module with the
+// shared packages, a go.work, and one Docker Compose file and Helm umbrella
+// chart for all services. Each service is generated by a TemplateGenerator.
+type PlatformGenerator struct {
+	Platform *project.Platform
+	Dir      string
+	// Force overwrites platform files that were changed since goback generated them
+	Force   bool
+	written []string
+	skipped []string
+}
+
+// NewPlatformGenerator creates a generator for the platform in dir
+func NewPlatformGenerator(platform *project.Platform, dir string) *PlatformGenerator {
+	return &PlatformGenerator{Platform: platform, Dir: dir}
+}
+
+// Written returns the platform files written, as slash separated paths
+func (pg *PlatformGenerator) Written() []string {
+	return pg.written
+}
+
+// Skipped returns the platform files left untouched because they were changed
+func (pg *PlatformGenerator) Skipped() []string {
+	return pg.skipped
+}
+
+// Generate writes every platform file and records the platform
+func (pg *PlatformGenerator) Generate() error {
+	root := path.Join(templatesDir, platformDir)
+	err := fs.WalkDir(scaffolding.Templates, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		return pg.generateFile(strings.TrimPrefix(p, root+"/"))
+	})
+	if err != nil {
+		return err
+	}
+	return pg.save()
+}
+
+// ServiceConfig returns the configuration of a new service based on the
+// platform: its module path, output directory, Go version and port, the
+// workspace mode that adds it to go.work, and the platform module whose shared
+// packages it imports
+func (pg *PlatformGenerator) ServiceConfig(name string) *config.ProjectConfig {
+	return &config.ProjectConfig{
+		ProjectName: name,
+		ModulePath:  pg.Platform.ModulePath + "/" + project.ServicesDir + "/" + name,
+		Description: fmt.Sprintf("%s service of the %s platform", name, pg.Platform.Name),
+		OutputDir:   filepath.Join(pg.Dir, project.ServicesDir, name),
+		RepoMode:    config.RepoWorkspace,
+		Platform:    pg.Platform.ModulePath,
+		GoVersion:   pg.Platform.GoVersion,
+		Values:      map[string]interface{}{"port": pg.Platform.NextPort()},
+	}
+}
+
+// AddService generates the service of cfg, records it in the platform and
+// rewrites the Docker Compose file and the Helm values for all services
+func (pg *PlatformGenerator) AddService(gen *TemplateGenerator) error {
+	cfg := gen.Config
+	if pg.Platform.Service(cfg.ProjectName) != nil {
+		return fmt.Errorf("the platform already has a service named %s", cfg.ProjectName)
+	}
+	port, err := strconv.Atoi(fmt.Sprint(cfg.Value("port", pg.Platform.NextPort())))
+	if err != nil {
+		return fmt.Errorf("invalid port %v: %w", cfg.Value("port", nil), err)
+	}
+
+	if err := gen.Generate(); err != nil {
+		return err
+	}
+	pg.Platform.AddService(cfg, port)
+
+	for _, file := range platformServiceFiles {
+		if err := pg.generateFile(file + ".tmpl"); err != nil {
+			return err
+		}
+	}
+	return pg.save()
+}
+
+// generateFile renders or copies the platform template at the slash separated
+// path rel, unless the file was changed since it was generated
+func (pg *PlatformGenerator) generateFile(rel string) error {
+	dest := strings.TrimSuffix(rel, ".tmpl")
+	if dest == "gitignore" {
+		dest = ".gitignore"
+	}
+	if !pg.Force && pg.Platform.State(pg.Dir, dest) == project.FileModified {
+		pg.skipped = append(pg.skipped, dest)
+		return nil
+	}
+
+	content, err := scaffolding.Templates.ReadFile(path.Join(templatesDir, platformDir, rel))
+	if err != nil {
+		return fmt.Errorf("failed to read embedded template %s: %w", rel, err)
+	}
+	if strings.HasSuffix(rel, ".tmpl") {
+		tmpl, err := template.New(path.Base(rel)).Funcs(templateFuncs()).Parse(string(content))
+		if err != nil {
+			return fmt.Errorf("failed to parse template %s: %w", rel, err)
+		}
+		var rendered bytes.Buffer
+		if err := tmpl.Execute(&rendered, pg.Platform); err != nil {
+			return fmt.Errorf("failed to execute template %s: %w", rel, err)
+		}
+		content = rendered.Bytes()
+	}
+
+	fullPath := filepath.Join(pg.Dir, filepath.FromSlash(dest))
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", fullPath, err)
+	}
+	if err := os.WriteFile(fullPath, content, 0644); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", fullPath, err)
+	}
+	pg.Platform.Files[dest] = project.HashContent(content)
+	pg.written = append(pg.written, dest)
+	return nil
+}
+
+// save records the platform in its root directory
+func (pg *PlatformGenerator) save() error {
+	if err := pg.Platform.Save(pg.Dir); err != nil {
+		return fmt.Errorf("failed to write %s: %w", project.PlatformPath, err)
+	}
+	return nil
+}
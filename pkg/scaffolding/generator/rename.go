@@ -0,0 +1,280 @@
+// pkg/scaffolding/generator/rename.go
+
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/NarmadaWeb/goback/pkg/project"
+	"github.com/iancoleman/strcase"
+	"golang.org/x/mod/modfile"
+)
+
+// renamedFiles are the deployment files in which the project name is replaced
+// as a whole word, in its kebab, snake, title and upper case forms
+var renamedFiles = []string{
+	"Dockerfile",
+	"docker-compose.yml",
+	devopsDir + "/helm/Chart.yaml",
+	devopsDir + "/helm/values.yaml",
+	devopsDir + "/helm/templates/*",
+	devopsDir + "/terraform/variables.tf",
+	devopsDir + "/ansible/inventory.ini",
+}
+
+// renameSkippedDirs are not searched for imports or mentions of the old name
+var renameSkippedDirs = []string{".git", ".goback", "vendor", "node_modules"}
+
+// Renamer changes the module path and the project name of an existing
+// project. The module path is rewritten in go.mod and, with go/ast, in every
+// import of the module's packages; nested modules are left alone. The project
+// name is replaced in the deployment files only, other files that still
+// mention it are reported.
+type Renamer struct {
+	Dir       string
+	Module    string
+	NewModule string
+	Name      string
+	NewName   string
+	// Manifest, when set, records the new hash of every rewritten file that
+	// was unchanged since generation, so that it stays pristine
+	Manifest *project.Manifest
+	changed  []string
+	mentions []string
+}
+
+// NewRenamer creates a renamer for the project in dir
+func NewRenamer(dir, module, newModule, name, newName string) *Renamer {
+	return &Renamer{Dir: dir, Module: module, NewModule: newModule, Name: name, NewName: newName}
+}
+
+// Changed returns the files rewritten, as sorted slash separated paths
+func (r *Renamer) Changed() []string {
+	return r.changed
+}
+
+// Mentions returns the files that still mention the old project name
+func (r *Renamer) Mentions() []string {
+	return r.mentions
+}
+
+// Rename rewrites the project files
+func (r *Renamer) Rename() error {
+	if r.NewModule != "" && r.NewModule != r.Module {
+		if err := r.renameModule(); err != nil {
+			return err
+		}
+	}
+	if r.NewName != "" && r.NewName != r.Name {
+		if err := r.renameProject(); err != nil {
+			return err
+		}
+	}
+	sort.Strings(r.changed)
+
+	if r.Manifest != nil {
+		if r.NewModule != "" {
+			r.Manifest.Config.ModulePath = r.NewModule
+		}
+		if r.NewName != "" {
+			if r.Manifest.Config.Description == fmt.Sprintf("%s backend API", r.Name) {
+				r.Manifest.Config.Description = fmt.Sprintf("%s backend API", r.NewName)
+			}
+			r.Manifest.Config.ProjectName = r.NewName
+		}
+	}
+	return nil
+}
+
+// renameModule rewrites the module path in go.mod and the imports of every Go file
+func (r *Renamer) renameModule() error {
+	content, err := os.ReadFile(filepath.Join(r.Dir, "go.mod"))
+	if err != nil {
+		return err
+	}
+	file, err := modfile.Parse("go.mod", content, nil)
+	if err != nil {
+		return err
+	}
+	if err := file.AddModuleStmt(r.NewModule); err != nil {
+		return err
+	}
+	// A replace of the module itself, e.g. "=> ./", follows the new path
+	var replaces []modfile.Replace
+	for _, rep := range file.Replace {
+		if rep.Old.Path == r.Module {
+			replaces = append(replaces, *rep)
+		}
+	}
+	for _, rep := range replaces {
+		if err := file.DropReplace(rep.Old.Path, rep.Old.Version); err != nil {
+			return err
+		}
+		if err := file.AddReplace(r.NewModule, rep.Old.Version, rep.New.Path, rep.New.Version); err != nil {
+			return err
+		}
+	}
+	file.Cleanup()
+	if err := r.writeFile("go.mod", content, modfile.Format(file.Syntax)); err != nil {
+		return err
+	}
+
+	return r.walk(func(relPath string, content []byte) error {
+		if !strings.HasSuffix(relPath, ".go") {
+			return nil
+		}
+		return r.writeFile(relPath, content, renameImports(relPath, content, r.Module, r.NewModule))
+	})
+}
+
+// renameImports replaces the import paths of oldPath and its packages with
+// newPath in place, so that the rest of the file keeps its formatting. A file
+// that was formatted with gofmt is formatted again to keep its imports sorted.
+// Files that do not parse are returned unchanged.
+func renameImports(name string, content []byte, oldPath, newPath string) []byte {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, name, content, parser.ImportsOnly)
+	if err != nil {
+		return content
+	}
+
+	var b bytes.Buffer
+	last := 0
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || importPath != oldPath && !strings.HasPrefix(importPath, oldPath+"/") {
+			continue
+		}
+		start, end := fset.Position(spec.Path.Pos()).Offset, fset.Position(spec.Path.End()).Offset
+		b.Write(content[last:start])
+		b.WriteString(strconv.Quote(newPath + strings.TrimPrefix(importPath, oldPath)))
+		last = end
+	}
+	if last == 0 {
+		return content
+	}
+	b.Write(content[last:])
+
+	if formatted, err := format.Source(content); err == nil && bytes.Equal(formatted, content) {
+		if formatted, err := format.Source(b.Bytes()); err == nil {
+			return formatted
+		}
+	}
+	return b.Bytes()
+}
+
+// renameProject replaces the project name in the deployment files and
+// collects the other files that mention it
+func (r *Renamer) renameProject() error {
+	var replacements, mentions []replacement
+	seen := map[string]bool{}
+	for _, form := range []func(string) string{
+		func(s string) string { return s },
+		strcase.ToKebab,
+		strcase.ToSnake,
+		titleCase,
+		func(s string) string { return strings.ToUpper(strcase.ToKebab(s)) },
+		func(s string) string { return strings.ToUpper(strcase.ToSnake(s)) },
+	} {
+		old := form(r.Name)
+		if old == "" || seen[old] {
+			continue
+		}
+		seen[old] = true
+		replacements = append(replacements, replacement{old: old, new: form(r.NewName), wholeWord: true})
+		mentions = append(mentions, replacement{old: old, new: "", wholeWord: true})
+	}
+	replace := newReplacer(replacements)
+	strip := newReplacer(mentions)
+
+	return r.walk(func(relPath string, content []byte) error {
+		if r.renamed(relPath) {
+			return r.writeFile(relPath, content, []byte(replace(string(content))))
+		}
+		// Report text files that mention the name anywhere
+		if !bytes.Contains(content, []byte{0}) && strip(string(content)) != string(content) {
+			r.mentions = append(r.mentions, relPath)
+		}
+		return nil
+	})
+}
+
+// renamed reports whether the project name is replaced in the file at relPath
+func (r *Renamer) renamed(relPath string) bool {
+	for _, pattern := range renamedFiles {
+		if matched, _ := filepath.Match(pattern, relPath); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// walk calls fn for every regular file of the project, skipping nested modules
+func (r *Renamer) walk(fn func(relPath string, content []byte) error) error {
+	return filepath.WalkDir(r.Dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(r.Dir, p)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		if d.IsDir() {
+			if relPath == "." {
+				return nil
+			}
+			for _, skipped := range renameSkippedDirs {
+				if d.Name() == skipped {
+					return filepath.SkipDir
+				}
+			}
+			if _, err := os.Stat(filepath.Join(p, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		return fn(relPath, content)
+	})
+}
+
+// writeFile writes the rewritten content of a file when it differs from the old content
+func (r *Renamer) writeFile(relPath string, old, content []byte) error {
+	if bytes.Equal(old, content) {
+		return nil
+	}
+
+	pristine := r.Manifest != nil && r.Manifest.State(r.Dir, relPath) == project.FilePristine
+	fullPath := filepath.Join(r.Dir, filepath.FromSlash(relPath))
+	info, err := os.Stat(fullPath)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(fullPath, content, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", fullPath, err)
+	}
+	if pristine {
+		r.Manifest.Record(relPath, content)
+	}
+	r.changed = append(r.changed, relPath)
+	return nil
+}
@@ -0,0 +1,122 @@
+// pkg/scaffolding/generator/rename_test.go
+
+package generator
+
+import "testing"
+
+func TestRenameImports(t *testing.T) {
+	const oldPath, newPath = "github.com/acme/api", "example.com/billing"
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "module packages",
+			in: `package main
+
+import (
+	"fmt"
+
+	"github.com/acme/api/internal/config"
+	"github.com/acme/api/internal/routes"
+)
+`,
+			want: `package main
+
+import (
+	"fmt"
+
+	"example.com/billing/internal/config"
+	"example.com/billing/internal/routes"
+)
+`,
+		},
+		{
+			name: "module root and named import",
+			in: `package main
+
+import api "github.com/acme/api"
+import cfg "github.com/acme/api/config"
+`,
+			want: `package main
+
+import api "example.com/billing"
+import cfg "example.com/billing/config"
+`,
+		},
+		{
+			name: "sorted again when gofmt clean",
+			in: `package main
+
+import (
+	"foo.org/lib"
+	"github.com/acme/api/internal/config"
+)
+`,
+			want: `package main
+
+import (
+	"example.com/billing/internal/config"
+	"foo.org/lib"
+)
+`,
+		},
+		{
+			name: "formatting kept when not gofmt clean",
+			in: `package main
+import (
+	"github.com/acme/api/internal/config"
+)
+func main() {  config.Load() }
+`,
+			want: `package main
+import (
+	"example.com/billing/internal/config"
+)
+func main() {  config.Load() }
+`,
+		},
+		{
+			name: "other modules with the same prefix",
+			in: `package main
+
+import (
+	"github.com/acme/api-client"
+	"github.com/acme/apis/v2"
+)
+`,
+			want: `package main
+
+import (
+	"github.com/acme/api-client"
+	"github.com/acme/apis/v2"
+)
+`,
+		},
+		{
+			name: "strings are not imports",
+			in: `package main
+
+const path = "github.com/acme/api/internal"
+`,
+			want: `package main
+
+const path = "github.com/acme/api/internal"
+`,
+		},
+		{
+			name: "does not parse",
+			in:   `package main import "github.com/acme/api/internal"`,
+			want: `package main import "github.com/acme/api/internal"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(renameImports("main.go", []byte(tt.in), oldPath, newPath)); got != tt.want {
+				t.Errorf("renameImports() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+// pkg/scaffolding/generator/report.go
+
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/NarmadaWeb/goback/pkg/config"
+	"github.com/NarmadaWeb/goback/pkg/project"
+)
+
+// gettingStartedPath is the generated guide that holds the generation report
+const gettingStartedPath = "GETTING_STARTED.md"
+
+// Report summarizes a generated project and what to do next
+type Report struct {
+	Config *config.ProjectConfig
+	// Layers counts the generated files per layer, sorted by layer
+	Layers []LayerCount
+	// Steps are the follow-up commands, in the order they must be run
+	Steps []ReportStep
+	// Notes are remarks that need no command
+	Notes []string
+	// Services are the Docker Compose services
+	Services    []string
+	DevOpsFiles []string
+}
+
+// LayerCount is the number of generated files in a layer
+type LayerCount struct {
+	Layer string
+	Files int
+}
+
+// ReportStep is a follow-up command
+type ReportStep struct {
+	Description string
+	Command     string
+}
+
+// Report builds the generation report from the files generated so far
+func (tg *TemplateGenerator) Report() *Report {
+	report := &Report{Config: tg.Config}
+
+	counts := map[string]int{}
+	var migrations []string
+	for file := range tg.files {
+		if file == gettingStartedPath {
+			continue
+		}
+		counts[layerOf(file)]++
+		if strings.HasPrefix(file, devopsDir+"/") {
+			report.DevOpsFiles = append(report.DevOpsFiles, file)
+		}
+		if strings.HasSuffix(file, ".up.sql") {
+			migrations = append(migrations, path.Dir(file))
+		}
+	}
+	for layer, files := range counts {
+		report.Layers = append(report.Layers, LayerCount{Layer: layer, Files: files})
+	}
+	sort.Slice(report.Layers, func(i, j int) bool { return report.Layers[i].Layer < report.Layers[j].Layer })
+	sort.Strings(report.DevOpsFiles)
+
+	if compose, err := tg.readOutput("docker-compose.yml"); err == nil {
+		report.Services = composeServices(compose)
+	}
+
+	switch tg.Config.RepoMode {
+	case config.RepoPackage:
+		report.Steps = append(report.Steps, ReportStep{"Download the dependencies added to the enclosing module's go.mod", "go mod tidy"})
+		report.Notes = append(report.Notes, "The project is a package of the enclosing module, so the Dockerfile's "+
+			"'COPY go.mod go.sum' needs the module root as its build context.")
+	case config.RepoWorkspace:
+		report.Steps = append(report.Steps, ReportStep{"Download the dependencies and create go.sum", "go mod tidy"})
+		if repo, err := project.DetectRepo(tg.OutputDir); err == nil && repo.WorkspaceFile() != "" {
+			report.Notes = append(report.Notes, fmt.Sprintf("%s uses the module, so it builds with the rest of the repository.", repo.WorkspaceFile()))
+		}
+	default:
+		if repo, err := project.DetectRepo(tg.OutputDir); err == nil && repo.WorkFile != "" && !repo.WorkspaceUses(tg.OutputDir) {
+			report.Steps = append(report.Steps, ReportStep{"Build the module on its own, outside the enclosing go.work", "export GOWORK=off"})
+			report.Notes = append(report.Notes, fmt.Sprintf("%s does not use the module, so the go command needs GOWORK=off "+
+				"to build it; use --repo-mode workspace to add it to the workspace instead.", repo.WorkFile))
+		}
+		report.Steps = append(report.Steps, ReportStep{"Download the dependencies and create go.sum", "go mod tidy"})
+	}
+	if tg.Config.Tool == config.ToolSqlc {
+		report.Steps = append(report.Steps, ReportStep{"Generate the Go code for the SQL queries (requires sqlc)", "sqlc generate"})
+	}
+	if tg.Config.Database.RequiresServer() {
+		service := strings.ToLower(tg.Config.Database.String())
+		if tg.Config.Database == config.DatabasepostgresQL {
+			service = "postgres"
+		}
+		command := "docker compose up -d " + service
+		if tg.Config.Platform != "" {
+			// The database of a service is in the Docker Compose file of its platform
+			command = fmt.Sprintf("docker compose -f ../../docker-compose.yml up -d %s-db", tg.Config.ProjectName)
+		}
+		report.Steps = append(report.Steps, ReportStep{
+			fmt.Sprintf("Start the %s database", tg.Config.Database.String()),
+			command,
+		})
+	}
+	kind := tg.Config.Kind
+	switch {
+	case tg.Config.Tool.HasMigrations() && kind.HasCLI():
+		report.Steps = append(report.Steps, ReportStep{
+			fmt.Sprintf("Migrate the models with %s", tg.Config.Tool.String()),
+			"go run ./cmd/cli migrate",
+		})
+	case tg.Config.Tool.HasMigrations():
+		starts := "the API starts"
+		if !kind.HasAPI() {
+			starts = "the worker starts"
+		}
+		report.Notes = append(report.Notes, fmt.Sprintf("%s migrates the models automatically when %s.", tg.Config.Tool.String(), starts))
+	case len(migrations) > 0:
+		sort.Strings(migrations)
+		report.Steps = append(report.Steps, ReportStep{
+			"Apply the database migrations (requires golang-migrate)",
+			"make migrate/up MIGRATION_PATH=./" + migrations[0],
+		})
+	}
+	switch {
+	case kind.HasCLI():
+		report.Steps = append(report.Steps, ReportStep{"List the CLI commands", "go run ./cmd/cli --help"})
+	case kind.HasAPI():
+		report.Steps = append(report.Steps, ReportStep{"Start the API (or 'make dev' for live reload)", "go run ./cmd/api"})
+	}
+	if kind.HasWorker() {
+		report.Steps = append(report.Steps, ReportStep{"Start the worker", "go run ./cmd/worker"})
+	}
+
+	return report
+}
+
+// Text renders the report for the terminal
+func (r *Report) Text() string {
+	var b strings.Builder
+
+	b.WriteString("Files by layer:\n")
+	for _, layer := range r.Layers {
+		fmt.Fprintf(&b, "  %-32s %d\n", layer.Layer, layer.Files)
+	}
+
+	b.WriteString("\nNext steps:\n")
+	fmt.Fprintf(&b, "  1. cd %s\n", r.Config.OutputDir)
+	for i, step := range r.Steps {
+		fmt.Fprintf(&b, "  %d. %-40s # %s\n", i+2, step.Command, step.Description)
+	}
+	for _, note := range r.Notes {
+		fmt.Fprintf(&b, "  Note: %s\n", note)
+	}
+
+	if len(r.Services) > 0 {
+		fmt.Fprintf(&b, "\n'docker compose up -d' starts: %s\n", strings.Join(r.Services, ", "))
+	}
+
+	if len(r.DevOpsFiles) > 0 {
+		b.WriteString("\nDevOps files:\n")
+		for _, file := range r.DevOpsFiles {
+			fmt.Fprintf(&b, "  %s\n", file)
+		}
+	}
+
+	fmt.Fprintf(&b, "\nThis report is saved in %s.\n", gettingStartedPath)
+	return b.String()
+}
+
+// Markdown renders the report as the getting started guide
+func (r *Report) Markdown() string {
+	var b strings.Builder
+	cfg := r.Config
+
+	fmt.Fprintf(&b, "# Getting Started with %s\n\n", cfg.ProjectName)
+	fmt.Fprintf(&b, "This project was generated by GoBack: %s using %s with %s, organized as %s.\n",
+		describeKind(cfg), cfg.Database.String(), cfg.Tool.String(), cfg.Architecture.String())
+
+	b.WriteString("\n## Next Steps\n\nRun these commands from the project root, in order:\n\n")
+	for i, step := range r.Steps {
+		fmt.Fprintf(&b, "%d. %s\n\n   ```bash\n   %s\n   ```\n\n", i+1, step.Description, step.Command)
+	}
+	for _, note := range r.Notes {
+		fmt.Fprintf(&b, "> %s\n\n", note)
+	}
+
+	b.WriteString("## Project Layout\n\n| Layer | Files |\n| :---- | ----: |\n")
+	for _, layer := range r.Layers {
+		fmt.Fprintf(&b, "| `%s` | %d |\n", layer.Layer, layer.Files)
+	}
+
+	if len(r.Services) > 0 {
+		b.WriteString("\n## Docker Compose\n\n`docker compose up -d` starts these services:\n\n")
+		for _, service := range r.Services {
+			fmt.Fprintf(&b, "- `%s`\n", service)
+		}
+	}
+
+	if len(r.DevOpsFiles) > 0 {
+		b.WriteString("\n## DevOps\n\n")
+		for _, file := range r.DevOpsFiles {
+			fmt.Fprintf(&b, "- `%s`\n", file)
+		}
+	}
+
+	return b.String()
+}
+
+// generateGettingStarted writes the generation report to the project
+func (tg *TemplateGenerator) generateGettingStarted() error {
+	return tg.writeFile(gettingStartedPath, []byte(tg.Report().Markdown()))
+}
+
+// readOutput returns a file generated so far
+func (tg *TemplateGenerator) readOutput(file string) ([]byte, error) {
+	if tg.rendered != nil {
+		if content, ok := tg.rendered[file]; ok {
+			return content, nil
+		}
+		return nil, os.ErrNotExist
+	}
+	return os.ReadFile(filepath.Join(tg.OutputDir, filepath.FromSlash(file)))
+}
+
+// describeKind names what the project builds, e.g. "a Go Chi API"
+func describeKind(cfg *config.ProjectConfig) string {
+	switch cfg.Kind {
+	case config.KindWorker:
+		return "a background worker"
+	case config.KindCLI:
+		return "a CLI tool"
+	case config.KindAPIWorker:
+		return fmt.Sprintf("a %s API with a background worker", cfg.Framework.String())
+	default:
+		return fmt.Sprintf("a %s API", cfg.Framework.String())
+	}
+}
+
+// layerOf returns the layer a generated file belongs to, named after its directory
+func layerOf(file string) string {
+	dir := path.Dir(file)
+	if dir == "." {
+		return "project root"
+	}
+
+	parts := strings.Split(dir, "/")
+	depth := 2
+	if parts[0] == "adapters" {
+		depth = 3
+	}
+	if len(parts) > depth {
+		parts = parts[:depth]
+	}
+	return strings.Join(parts, "/")
+}
+
+// composeServices returns the service names of a docker-compose.yml file
+func composeServices(compose []byte) []string {
+	var services []string
+	inServices := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(compose))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		switch {
+		case indent == 0:
+			inServices = trimmed == "services:"
+		case inServices && indent == 2 && strings.HasSuffix(trimmed, ":"):
+			services = append(services, strings.TrimSuffix(trimmed, ":"))
+		}
+	}
+	return services
+}
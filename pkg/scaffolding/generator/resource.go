@@ -0,0 +1,290 @@
+// pkg/scaffolding/generator/resource.go
+
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/NarmadaWeb/goback/pkg/config"
+	"github.com/iancoleman/strcase"
+)
+
+// FieldType is the type of a resource field as given on the command line
+type FieldType string
+
+// Field types
+const (
+	FieldString  FieldType = "string"
+	FieldText    FieldType = "text"
+	FieldInt     FieldType = "int"
+	FieldFloat   FieldType = "float"
+	FieldDecimal FieldType = "decimal"
+	FieldBool    FieldType = "bool"
+	FieldTime    FieldType = "time"
+)
+
+// fieldTypeAliases maps accepted spellings to field types
+var fieldTypeAliases = map[string]FieldType{
+	"string":    FieldString,
+	"text":      FieldText,
+	"int":       FieldInt,
+	"integer":   FieldInt,
+	"int64":     FieldInt,
+	"float":     FieldFloat,
+	"float64":   FieldFloat,
+	"decimal":   FieldDecimal,
+	"bool":      FieldBool,
+	"boolean":   FieldBool,
+	"time":      FieldTime,
+	"datetime":  FieldTime,
+	"timestamp": FieldTime,
+}
+
+// GetValidFieldTypes returns the field types accepted by ParseFields
+func GetValidFieldTypes() []FieldType {
+	return []FieldType{FieldString, FieldText, FieldInt, FieldFloat, FieldDecimal, FieldBool, FieldTime}
+}
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// reservedFields are generated for every resource and cannot be declared
+var reservedFields = map[string]bool{"id": true, "created_at": true, "updated_at": true}
+
+// ResourceField is a single field of a generated resource
+type ResourceField struct {
+	Name   string // Go field name, e.g. UnitPrice
+	Column string // Column and JSON name, e.g. unit_price
+	Type   FieldType
+}
+
+// GoType returns the Go type used for the field in entities and DTOs
+func (f ResourceField) GoType() string {
+	switch f.Type {
+	case FieldInt:
+		return "int64"
+	case FieldFloat, FieldDecimal:
+		return "float64"
+	case FieldBool:
+		return "bool"
+	case FieldTime:
+		return "time.Time"
+	default:
+		return "string"
+	}
+}
+
+// Validate returns the validate tag applied to the field when creating a resource.
+// Numbers and booleans are not required because their zero value is meaningful.
+func (f ResourceField) Validate() string {
+	switch f.Type {
+	case FieldString:
+		return "required,max=255"
+	case FieldText, FieldTime:
+		return "required"
+	default:
+		return ""
+	}
+}
+
+// Label returns the field name in words, e.g. "unit price"
+func (f ResourceField) Label() string {
+	return strings.ReplaceAll(f.Column, "_", " ")
+}
+
+// Resource describes a resource generated by `goback add resource`
+type Resource struct {
+	Name   string // Singular Go type name, e.g. OrderItem
+	Fields []ResourceField
+}
+
+// Var returns the name used for local variables, e.g. orderItem
+func (r *Resource) Var() string {
+	return strcase.ToLowerCamel(r.Name)
+}
+
+// Snake returns the snake case name used for file names, e.g. order_item
+func (r *Resource) Snake() string {
+	return strcase.ToSnake(r.Name)
+}
+
+// Label returns the name in words used in messages, e.g. "order item"
+func (r *Resource) Label() string {
+	return strings.ReplaceAll(r.Snake(), "_", " ")
+}
+
+// ALabel returns the label with its indefinite article, e.g. "an order item"
+func (r *Resource) ALabel() string {
+	label := r.Label()
+	if strings.ContainsRune("aeiou", rune(label[0])) {
+		return "an " + label
+	}
+	return "a " + label
+}
+
+// Plural returns the plural Go name, e.g. OrderItems
+func (r *Resource) Plural() string {
+	return pluralize(r.Name)
+}
+
+// PluralVar returns the plural local variable name, e.g. orderItems
+func (r *Resource) PluralVar() string {
+	return strcase.ToLowerCamel(r.Plural())
+}
+
+// Table returns the database table and JSON list name, e.g. order_items
+func (r *Resource) Table() string {
+	return strcase.ToSnake(r.Plural())
+}
+
+// Path returns the URL path segment, e.g. order-items
+func (r *Resource) Path() string {
+	return strcase.ToKebab(r.Plural())
+}
+
+// ParseResource builds a resource from its name and a field list such as
+// "name:string,price:decimal,stock:int".
+func ParseResource(name, fields string) (*Resource, error) {
+	if !identifierPattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid resource name %q: use letters, digits and underscores, starting with a letter", name)
+	}
+	resource := &Resource{Name: goName(strcase.ToSnake(name))}
+	if resource.Snake() == "user" {
+		return nil, fmt.Errorf("the User resource is part of the generated sample and already exists")
+	}
+
+	parsed, err := ParseFields(fields)
+	if err != nil {
+		return nil, err
+	}
+	resource.Fields = parsed
+	return resource, nil
+}
+
+// ParseFields parses a comma separated list of name:type pairs
+func ParseFields(spec string) ([]ResourceField, error) {
+	var fields []ResourceField
+	seen := map[string]bool{}
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, typeName, found := strings.Cut(part, ":")
+		if !found {
+			typeName = string(FieldString)
+		}
+		name = strings.TrimSpace(name)
+		if !identifierPattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid field name %q", name)
+		}
+
+		fieldType, ok := fieldTypeAliases[strings.ToLower(strings.TrimSpace(typeName))]
+		if !ok {
+			return nil, fmt.Errorf("unknown type %q for field %s (valid types: %s)", typeName, name, joinFieldTypes())
+		}
+
+		column := strcase.ToSnake(name)
+		if reservedFields[column] {
+			return nil, fmt.Errorf("field %s is generated automatically and cannot be declared", name)
+		}
+		if seen[column] {
+			return nil, fmt.Errorf("field %s is declared more than once", name)
+		}
+		seen[column] = true
+
+		fields = append(fields, ResourceField{Name: goName(column), Column: column, Type: fieldType})
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("at least one field is required, e.g. --fields name:string,price:decimal")
+	}
+	return fields, nil
+}
+
+func joinFieldTypes() string {
+	var names []string
+	for _, t := range GetValidFieldTypes() {
+		names = append(names, string(t))
+	}
+	return strings.Join(names, ", ")
+}
+
+// goName converts a snake case name to a Go identifier the way sqlc does,
+// so that generated query parameters and entity fields line up.
+func goName(snake string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(snake, "_") {
+		if part == "" {
+			continue
+		}
+		if part == "id" {
+			b.WriteString("ID")
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// pluralize returns the English plural of a Go identifier
+func pluralize(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+		return name[:len(name)-1] + "ies"
+	case strings.HasSuffix(lower, "s"), strings.HasSuffix(lower, "x"), strings.HasSuffix(lower, "z"),
+		strings.HasSuffix(lower, "ch"), strings.HasSuffix(lower, "sh"):
+		return name + "es"
+	default:
+		return name + "s"
+	}
+}
+
+// sqlColumnType returns the column type of a field for the project's database.
+// sqlc maps DECIMAL to driver specific types, so it stores decimals as floating point.
+func sqlColumnType(cfg *config.ProjectConfig, f ResourceField) string {
+	db := cfg.Database
+	switch f.Type {
+	case FieldText:
+		return "TEXT"
+	case FieldInt:
+		if db == config.DatabaseSQLite {
+			return "INTEGER"
+		}
+		return "BIGINT"
+	case FieldDecimal:
+		if cfg.Tool != config.ToolSqlc && db != config.DatabaseSQLite {
+			return "DECIMAL(12,2)"
+		}
+		return sqlFloatType(db)
+	case FieldFloat:
+		return sqlFloatType(db)
+	case FieldBool:
+		return "BOOLEAN"
+	case FieldTime:
+		if db == config.DatabasepostgresQL {
+			return "TIMESTAMP WITH TIME ZONE"
+		}
+		return "DATETIME"
+	default:
+		if db == config.DatabaseSQLite {
+			return "TEXT"
+		}
+		return "VARCHAR(255)"
+	}
+}
+
+func sqlFloatType(db config.DatabaseChoice) string {
+	switch db {
+	case config.DatabasepostgresQL:
+		return "DOUBLE PRECISION"
+	case config.DatabaseMySQL:
+		return "DOUBLE"
+	default:
+		return "REAL"
+	}
+}
@@ -0,0 +1,567 @@
+// pkg/scaffolding/generator/resource_generator.go
+
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/NarmadaWeb/goback/pkg/config"
+	"github.com/NarmadaWeb/goback/pkg/project"
+	"github.com/NarmadaWeb/goback/pkg/scaffolding"
+	"github.com/iancoleman/strcase"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	resourcesDir        = "resources"
+	resourcePartialsDir = "partials"
+	resourceSQLDir      = "sql"
+	defaultMigrationDir = "db/migrations"
+)
+
+var migrationVersion = regexp.MustCompile(`^([0-9]+)_`)
+
+// ResourceData is the data passed to resource templates
+type ResourceData struct {
+	*config.ProjectConfig
+	Resource *Resource
+	Sqlc     SqlcLayout
+	// Ref holds package qualifiers and type names set by the including
+	// template through the refs function, so partials can be shared by
+	// all architectures.
+	Ref map[string]string
+}
+
+// RawID returns the Go type stored in the id column
+func (d *ResourceData) RawID() string {
+	if d.Database == config.DatabasepostgresQL {
+		return "uuid.UUID"
+	}
+	return "int64"
+}
+
+// ToolPrefix returns the prefix of repository implementation names, e.g. Gorm
+func (d *ResourceData) ToolPrefix() string {
+	return strcase.ToCamel(string(d.Tool))
+}
+
+// SqlcLayout holds the directories configured in sqlc.yaml
+type SqlcLayout struct {
+	Schema  string
+	Queries string
+	Out     string
+}
+
+// resourceLayout locates the packages a resource is wired through
+type resourceLayout struct {
+	repoDir, repoPkg, repoCtor          string
+	serviceDir, servicePkg, serviceCtor string
+	handlerDir, handlerPkg              string
+}
+
+// ResourceGenerator adds a CRUD resource to an existing project
+type ResourceGenerator struct {
+	*TemplateGenerator
+	Resource *Resource
+	Force    bool
+	// Manifest, when set, records the hashes of the resource files and of the
+	// patched files that were unchanged since goback generated them
+	Manifest *project.Manifest
+
+	data     *ResourceData
+	partials *template.Template
+	created  []string
+	notes    []string
+	// userFiles are patched files that were changed or not generated by goback
+	userFiles []string
+}
+
+// NewResourceGenerator creates a generator for resource in the project described by cfg
+func NewResourceGenerator(cfg *config.ProjectConfig, resource *Resource) *ResourceGenerator {
+	return &ResourceGenerator{
+		TemplateGenerator: NewTemplateGenerator(cfg),
+		Resource:          resource,
+		data:              &ResourceData{ProjectConfig: cfg, Resource: resource},
+	}
+}
+
+// Created returns the files written or updated, relative to the project root
+func (rg *ResourceGenerator) Created() []string {
+	return rg.created
+}
+
+// Notes returns the follow-up steps that could not be done automatically
+func (rg *ResourceGenerator) Notes() []string {
+	return rg.notes
+}
+
+// Generate writes the resource files and wires them into the project
+func (rg *ResourceGenerator) Generate() error {
+	steps := []struct {
+		name    string
+		handler func() error
+	}{
+		{"Checking project", rg.prepare},
+		{"Generating resource files", rg.generateResourceFiles},
+		{"Generating migration", rg.generateMigration},
+		{"Registering routes", rg.registerRoutes},
+	}
+
+	for i, step := range steps {
+		rg.reportProgress(i, fmt.Sprintf("Step %d/%d: %s", i+1, len(steps), step.name))
+
+		if err := step.handler(); err != nil {
+			rg.reportError(i, err)
+			return fmt.Errorf("step %d (%s) failed: %w", i+1, step.name, err)
+		}
+	}
+
+	if rg.Manifest != nil {
+		for path, hash := range rg.files {
+			if !slices.Contains(rg.userFiles, path) {
+				rg.Manifest.Files[path] = hash
+			}
+		}
+	}
+
+	rg.reportProgress(len(steps), fmt.Sprintf("Resource %s added successfully!", rg.Resource.Name))
+	return nil
+}
+
+func (rg *ResourceGenerator) prepare() error {
+	cfg := rg.Config
+	if !cfg.Kind.HasAPI() {
+		return fmt.Errorf("resources add HTTP handlers, but the %s kind has no HTTP API", cfg.Kind)
+	}
+	if cfg.Framework == "" || cfg.Database == "" || cfg.Tool == "" || cfg.Architecture == "" {
+		return fmt.Errorf("framework, database, tool and architecture must be known to add a resource")
+	}
+
+	layout := rg.layout()
+	handlerFile := path.Join(layout.handlerDir, rg.Resource.Snake()+"_handler.go")
+	if _, err := os.Stat(filepath.Join(rg.OutputDir, handlerFile)); err == nil && !rg.Force {
+		return fmt.Errorf("resource %s already exists (%s), use --force to overwrite it", rg.Resource.Name, handlerFile)
+	}
+
+	if cfg.Tool == config.ToolSqlc {
+		layout, err := readSqlcLayout(rg.readOutput)
+		if err != nil {
+			return err
+		}
+		rg.data.Sqlc = layout
+	}
+
+	funcs := templateFuncs()
+	funcs["sqlType"] = func(f ResourceField) string { return sqlColumnType(cfg, f) }
+	funcs["refs"] = withRefs
+	funcs["add"] = func(a, b int) int { return a + b }
+
+	pattern := path.Join(templatesDir, resourcesDir, resourcePartialsDir, "*.tmpl")
+	partials, err := template.New(resourcesDir).Funcs(funcs).ParseFS(scaffolding.Templates, pattern)
+	if err != nil {
+		return fmt.Errorf("failed to parse resource templates: %w", err)
+	}
+	rg.partials = partials
+	return nil
+}
+
+// generateResourceFiles renders the templates of the project's architecture.
+// File names containing "resource" are renamed after the resource.
+func (rg *ResourceGenerator) generateResourceFiles() error {
+	templateRootDir := path.Join(templatesDir, resourcesDir, string(rg.Config.Architecture))
+
+	return fs.WalkDir(scaffolding.Templates, templateRootDir, func(templatePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(templatePath, ".tmpl") {
+			return nil
+		}
+
+		relPath := strings.TrimPrefix(templatePath, templateRootDir+"/")
+		dir, base := path.Split(strings.TrimSuffix(relPath, ".tmpl"))
+		destPath := path.Join(dir, strings.Replace(base, "resource", rg.Resource.Snake(), 1))
+
+		return rg.renderFile(destPath, templatePath, rg.data)
+	})
+}
+
+// renderFile executes an embedded resource template and writes the result,
+// formatting Go files
+func (rg *ResourceGenerator) renderFile(destPath, templatePath string, data interface{}) error {
+	content, err := scaffolding.Templates.ReadFile(templatePath)
+	if err != nil {
+		return fmt.Errorf("failed to read embedded template %s: %w", templatePath, err)
+	}
+
+	tmpl, err := rg.partials.Clone()
+	if err != nil {
+		return err
+	}
+	if _, err := tmpl.New(templatePath).Parse(string(content)); err != nil {
+		return fmt.Errorf("failed to parse template %s: %w", templatePath, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, templatePath, data); err != nil {
+		return fmt.Errorf("failed to execute template %s: %w", templatePath, err)
+	}
+
+	output := buf.Bytes()
+	if strings.HasSuffix(destPath, ".go") {
+		if output, err = format.Source(output); err != nil {
+			return fmt.Errorf("failed to format %s: %w", destPath, err)
+		}
+	}
+
+	if err := rg.writeFile(destPath, output); err != nil {
+		return err
+	}
+	rg.created = append(rg.created, destPath)
+	return nil
+}
+
+// generateMigration creates the table for the resource. GORM projects
+// register the persistence model with AutoMigrate, the others get SQL
+// migration files next to the existing ones.
+func (rg *ResourceGenerator) generateMigration() error {
+	if rg.Config.Tool == config.ToolGorm {
+		return rg.registerGormModel()
+	}
+
+	migrationDir := rg.data.Sqlc.Schema
+	if migrationDir == "" {
+		migrationDir = rg.findMigrationDir()
+	}
+
+	name, err := rg.migrationName(migrationDir)
+	if err != nil {
+		return err
+	}
+
+	sqlDir := path.Join(templatesDir, resourcesDir, resourceSQLDir)
+	for _, direction := range []string{"up", "down"} {
+		destPath := filepath.ToSlash(filepath.Join(migrationDir, name+"."+direction+".sql"))
+		templatePath := path.Join(sqlDir, "create_table."+direction+".sql.tmpl")
+		if err := rg.renderFile(destPath, templatePath, rg.data); err != nil {
+			return err
+		}
+	}
+
+	if rg.Config.Tool == config.ToolSqlc {
+		destPath := filepath.ToSlash(filepath.Join(rg.data.Sqlc.Queries, rg.Resource.Table()+".sql"))
+		if err := rg.renderFile(destPath, path.Join(sqlDir, "queries.sql.tmpl"), rg.data); err != nil {
+			return err
+		}
+		rg.notes = append(rg.notes, "Run `sqlc generate` to generate the query code used by the repository")
+	}
+	return nil
+}
+
+// findMigrationDir returns the directory holding the project's existing
+// *.up.sql migrations
+func (rg *ResourceGenerator) findMigrationDir() string {
+	found := ""
+	_ = filepath.WalkDir(rg.OutputDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || found != "" {
+			return filepath.SkipDir
+		}
+		if d.IsDir() && (d.Name() == ".git" || d.Name() == "vendor" || d.Name() == "node_modules") {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && strings.HasSuffix(d.Name(), ".up.sql") {
+			if rel, relErr := filepath.Rel(rg.OutputDir, filepath.Dir(p)); relErr == nil {
+				found = rel
+			}
+			return filepath.SkipAll
+		}
+		return nil
+	})
+
+	if found == "" {
+		return defaultMigrationDir
+	}
+	return found
+}
+
+// migrationName returns the base name of the resource migration, numbered
+// after the existing migrations in dir. A migration for the same table is
+// reused so --force regenerates it in place.
+func (rg *ResourceGenerator) migrationName(dir string) (string, error) {
+	suffix := "_create_" + rg.Resource.Table() + "_table"
+
+	entries, err := os.ReadDir(filepath.Join(rg.OutputDir, dir))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+
+	latest, width := 0, 5
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasSuffix(name, suffix+".up.sql") {
+			return strings.TrimSuffix(name, ".up.sql"), nil
+		}
+		match := migrationVersion.FindStringSubmatch(name)
+		if match == nil {
+			continue
+		}
+		if version, convErr := strconv.Atoi(match[1]); convErr == nil && version > latest {
+			latest, width = version, len(match[1])
+		}
+	}
+	return fmt.Sprintf("%0*d%s", width, latest+1, suffix), nil
+}
+
+// registerGormModel adds the persistence model to the AutoMigrate call
+func (rg *ResourceGenerator) registerGormModel() error {
+	migratePath := rg.getDestinationPath("migrate")
+	model := rg.Resource.Name + "PersistenceModel{}"
+	manual := fmt.Sprintf("Add %s.%s to the AutoMigrate call of your project", rg.layout().repoPkg, model)
+
+	patch, err := rg.loadSourcePatch(migratePath)
+	if err != nil {
+		rg.notes = append(rg.notes, manual)
+		return nil
+	}
+
+	var call *ast.CallExpr
+	ast.Inspect(patch.file, func(n ast.Node) bool {
+		if c, ok := n.(*ast.CallExpr); ok && call == nil {
+			if sel, ok := c.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "AutoMigrate" {
+				call = c
+			}
+		}
+		return call == nil
+	})
+	if call == nil {
+		rg.notes = append(rg.notes, manual)
+		return nil
+	}
+	if bytes.Contains(patch.src, []byte("."+model)) {
+		return nil
+	}
+
+	layout := rg.layout()
+	name := patch.importName(rg.Config.ModulePath+"/"+layout.repoDir, layout.repoPkg)
+	entry := name + "." + model
+
+	if len(call.Args) == 0 {
+		patch.insert(call.Rparen, entry)
+	} else {
+		last := call.Args[len(call.Args)-1]
+		between := patch.src[patch.fset.Position(last.End()).Offset:patch.fset.Position(call.Rparen).Offset]
+		if bytes.Contains(between, []byte(",")) {
+			patch.insert(call.Rparen, entry+",\n")
+		} else {
+			patch.insert(last.End(), ", "+entry)
+		}
+	}
+
+	return rg.writePatch(migratePath, patch)
+}
+
+// registerRoutes appends the resource routes to the Setup function of the
+// project's routes file
+func (rg *ResourceGenerator) registerRoutes() error {
+	routesPath := rg.getDestinationPath("routes")
+	layout := rg.layout()
+
+	patch, err := rg.loadSourcePatch(routesPath)
+	if err != nil {
+		rg.notes = append(rg.notes, fmt.Sprintf("Could not open %s, register the %s routes manually", routesPath, rg.Resource.Path()))
+		return nil
+	}
+	setup := patch.funcDecl("Setup")
+	if setup == nil || setup.Body == nil {
+		rg.notes = append(rg.notes, fmt.Sprintf("No Setup function in %s, register the %s routes manually", routesPath, rg.Resource.Path()))
+		return nil
+	}
+	if bytes.Contains(patch.src, []byte(`"/api/v1/`+rg.Resource.Path()+`"`)) {
+		return nil
+	}
+
+	qualify := func(dir, pkg string) string {
+		if path.Dir(routesPath) == dir {
+			return ""
+		}
+		return patch.importName(rg.Config.ModulePath+"/"+dir, pkg) + "."
+	}
+	data, err := withRefs(rg.data,
+		"NewRepo", qualify(layout.repoDir, layout.repoPkg)+layout.repoCtor,
+		"NewService", qualify(layout.serviceDir, layout.servicePkg)+layout.serviceCtor,
+		"NewHandler", qualify(layout.handlerDir, layout.handlerPkg)+"New"+rg.Resource.Name+"Handler",
+	)
+	if err != nil {
+		return err
+	}
+
+	var snippet bytes.Buffer
+	if err := rg.partials.ExecuteTemplate(&snippet, "routes", data); err != nil {
+		return fmt.Errorf("failed to render routes: %w", err)
+	}
+	patch.insert(setup.Body.Rbrace, "\n"+snippet.String()+"\n")
+
+	return rg.writePatch(routesPath, patch)
+}
+
+// loadSourcePatch parses a project file, which may have been generated in
+// the same run
+func (rg *ResourceGenerator) loadSourcePatch(file string) (*sourcePatch, error) {
+	src, err := rg.readOutput(file)
+	if err != nil {
+		return nil, err
+	}
+	return parseSourcePatch(file, src)
+}
+
+// writePatch writes a patched project file. Unlike writeFile it adds no
+// header, the file already has one if the project uses them.
+func (rg *ResourceGenerator) writePatch(destPath string, patch *sourcePatch) error {
+	content, err := patch.apply()
+	if err != nil {
+		return err
+	}
+	// The patched file stays the user's when they changed or wrote it
+	if rg.Manifest != nil && rg.Manifest.State(rg.OutputDir, destPath) != project.FilePristine {
+		rg.userFiles = append(rg.userFiles, destPath)
+	}
+	rg.created = append(rg.created, destPath)
+	rg.files[destPath] = project.HashContent(content)
+	if rg.rendered != nil {
+		rg.rendered[destPath] = content
+		return nil
+	}
+	if err := os.WriteFile(filepath.Join(rg.OutputDir, destPath), content, 0644); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", destPath, err)
+	}
+	return nil
+}
+
+func (rg *ResourceGenerator) layout() resourceLayout {
+	name := rg.Resource.Name
+	repoCtor := "New" + rg.data.ToolPrefix() + name + "Repository"
+
+	switch rg.Config.Architecture {
+	case config.ArchitectureDDD:
+		return resourceLayout{
+			repoDir: "infrastructure/repositories", repoPkg: "repositories", repoCtor: repoCtor,
+			serviceDir: "domain/services", servicePkg: "services", serviceCtor: "New" + name + "ApplicationService",
+			handlerDir: "interfaces/handlers", handlerPkg: "handlers",
+		}
+	case config.ArchitectureClean:
+		return resourceLayout{
+			repoDir: "infrastructure/repositories", repoPkg: "repositories", repoCtor: repoCtor,
+			serviceDir: "domain/usecases", servicePkg: "usecases", serviceCtor: "New" + name + "Usecase",
+			handlerDir: "interfaces/handlers", handlerPkg: "handlers",
+		}
+	case config.ArchitectureHexagonal:
+		return resourceLayout{
+			repoDir: "adapters/secondary/databases", repoPkg: "database", repoCtor: repoCtor,
+			serviceDir: "applications/services", servicePkg: "services", serviceCtor: "New" + name + "Service",
+			handlerDir: "adapters/primary/http", handlerPkg: "http",
+		}
+	default:
+		return resourceLayout{
+			repoDir: "internal/repositories", repoPkg: "repositories", repoCtor: "New" + name + "Repository",
+			serviceDir: "internal/services", servicePkg: "services", serviceCtor: "New" + name + "Service",
+			handlerDir: "internal/handlers", handlerPkg: "handlers",
+		}
+	}
+}
+
+// withRefs returns a copy of the data with Ref set from key/value pairs.
+// It is available to templates as refs.
+func withRefs(data *ResourceData, pairs ...string) (*ResourceData, error) {
+	if len(pairs)%2 != 0 {
+		return nil, fmt.Errorf("refs expects key/value pairs, got %d arguments", len(pairs))
+	}
+
+	ref := make(map[string]string, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		ref[pairs[i]] = pairs[i+1]
+	}
+
+	copied := *data
+	copied.Ref = ref
+	return &copied, nil
+}
+
+// sqlcConfig is the part of sqlc.yaml needed to place queries and migrations
+type sqlcConfig struct {
+	SQL []struct {
+		Schema  interface{} `yaml:"schema"`
+		Queries interface{} `yaml:"queries"`
+		Gen     struct {
+			Go struct {
+				Out string `yaml:"out"`
+			} `yaml:"go"`
+		} `yaml:"gen"`
+	} `yaml:"sql"`
+}
+
+// readSqlcLayout reads the schema, queries and output directories from sqlc.yaml
+func readSqlcLayout(read func(file string) ([]byte, error)) (SqlcLayout, error) {
+	var data []byte
+	var err error
+	for _, name := range []string{"sqlc.yaml", "sqlc.yml"} {
+		if data, err = read(name); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return SqlcLayout{}, fmt.Errorf("failed to read sqlc.yaml: %w", err)
+	}
+
+	var cfg sqlcConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return SqlcLayout{}, fmt.Errorf("failed to parse sqlc.yaml: %w", err)
+	}
+	if len(cfg.SQL) == 0 {
+		return SqlcLayout{}, fmt.Errorf("sqlc.yaml has no sql entries")
+	}
+
+	entry := cfg.SQL[0]
+	layout := SqlcLayout{
+		Schema:  sqlcDir(entry.Schema),
+		Queries: sqlcDir(entry.Queries),
+		Out:     path.Clean(entry.Gen.Go.Out),
+	}
+	if layout.Schema == "" || layout.Queries == "" || entry.Gen.Go.Out == "" {
+		return SqlcLayout{}, fmt.Errorf("sqlc.yaml must define schema, queries and gen.go.out")
+	}
+	return layout, nil
+}
+
+// sqlcDir returns the directory of a sqlc path setting, which may be a
+// single path or a list and may name a file
+func sqlcDir(value interface{}) string {
+	var p string
+	switch v := value.(type) {
+	case string:
+		p = v
+	case []interface{}:
+		if len(v) > 0 {
+			p, _ = v[0].(string)
+		}
+	}
+	if p == "" {
+		return ""
+	}
+	if strings.HasSuffix(p, ".sql") {
+		return path.Dir(p)
+	}
+	return path.Clean(p)
+}
@@ -0,0 +1,51 @@
+// pkg/scaffolding/generator/sample.go
+
+package generator
+
+import (
+	"path"
+	"strings"
+)
+
+// userSampleTemplates are the templates of the built-in User sample whose
+// names do not start with "user"
+var userSampleTemplates = map[string]bool{
+	"architectures/hexagonal/adapters/primary/http/handlers.go.tmpl": true,
+	"tools/sqlx/model.go.tmpl":                                       true,
+	"tools/sqlc/db/queries/query.sql.tmpl":                           true,
+}
+
+// isUserSampleTemplate reports whether a template belongs to the built-in User sample
+func isUserSampleTemplate(templatePath string) bool {
+	base := path.Base(templatePath)
+	return userSampleTemplates[templatePath] ||
+		strings.HasPrefix(base, "user") ||
+		strings.Contains(base, "_create_users_table.")
+}
+
+// generateSample renders the CRUD resource of a custom sample entity and
+// wires it into the routes and migrations generated before
+func (tg *TemplateGenerator) generateSample() error {
+	entity := tg.Config.SampleEntity()
+	if entity == "" {
+		return nil
+	}
+
+	resource, err := ParseResource(entity, tg.Config.SampleFieldSpec())
+	if err != nil {
+		return err
+	}
+
+	rg := &ResourceGenerator{
+		TemplateGenerator: tg,
+		Resource:          resource,
+		Force:             true,
+		data:              &ResourceData{ProjectConfig: tg.Config, Resource: resource},
+	}
+	for _, step := range []func() error{rg.prepare, rg.generateResourceFiles, rg.generateMigration, rg.registerRoutes} {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+	return nil
+}